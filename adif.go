@@ -0,0 +1,472 @@
+package cabrillo
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// adifRecord is a record of an ADIF file mapping upper case field names to
+// their values.
+type adifRecord map[string]string
+
+// adifBand maps a band to its ADIF name and the frequency designator used in
+// QSO lines for 50 MHz and up.
+type adifBand struct {
+	band       string
+	adif       string
+	designator string
+}
+
+var adifBands = []adifBand{
+	{Band160M, "160m", ""},
+	{Band80M, "80m", ""},
+	{Band60M, "60m", ""},
+	{Band40M, "40m", ""},
+	{Band30M, "30m", ""},
+	{Band20M, "20m", ""},
+	{Band17M, "17m", ""},
+	{Band15M, "15m", ""},
+	{Band12M, "12m", ""},
+	{Band10M, "10m", ""},
+	{Band6M, "6m", "50"},
+	{Band4M, "4m", "70"},
+	{Band2M, "2m", "144"},
+	{Band222, "1.25m", "222"},
+	{Band432, "70cm", "432"},
+	{Band902, "33cm", "902"},
+	{Band1200, "23cm", "1.2G"},
+	{Band2300, "13cm", "2.3G"},
+	{Band3400, "9cm", "3.4G"},
+	{Band5700, "6cm", "5.7G"},
+	{Band10G, "3cm", "10G"},
+	{Band24G, "1.25cm", "24G"},
+	{Band47G, "6mm", "47G"},
+	{Band75G, "4mm", "75G"},
+	{Band123G, "2.5mm", "123G"},
+	{Band134G, "2mm", "134G"},
+	{Band241G, "1mm", "241G"},
+	{BandLight, "submm", "LIGHT"},
+}
+
+// adifModes maps ADIF modes and submodes to Cabrillo modes. Modes not listed
+// are digital modes.
+var adifModes = map[string]string{
+	"CW":           "CW",
+	"SSB":          "PH",
+	"USB":          "PH",
+	"LSB":          "PH",
+	"AM":           "PH",
+	"DIGITALVOICE": "PH",
+	"FM":           "FM",
+	"RTTY":         "RY",
+	"ASCI":         "RY",
+}
+
+// ParseADIF builds a log from an ADIF file, either in the ADI or in the ADX
+// (XML) format. ADIF files have no equivalent to the Cabrillo header, so the
+// header fields are copied from header. Each ADIF record becomes a QSO:
+//
+//   - FREQ (in MHz) becomes the frequency in kHz or, for 50 MHz and up, the
+//     band designator. Without FREQ, the lower edge of BAND is used.
+//   - MODE and SUBMODE become CW, PH, FM or RY, any other mode becomes DG.
+//   - QSO_DATE and TIME_ON become the timestamp.
+//   - STATION_CALLSIGN (or OPERATOR, or the callsign of the header) and CALL
+//     become the sent and received callsigns.
+//   - RST_SENT and RST_RCVD become the signal reports. Reports that aren't
+//     RST, like the dB reports of FT8, are left empty.
+//   - STX followed by STX_STRING and SRX followed by SRX_STRING become the
+//     sent and received exchanges.
+func ParseADIF(r io.Reader, header Log) (Log, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Log{}, err
+	}
+
+	var records []adifRecord
+	trimmed := bytes.ToUpper(bytes.TrimSpace(data))
+	if bytes.HasPrefix(trimmed, []byte("<?XML")) || bytes.HasPrefix(trimmed, []byte("<ADX")) {
+		records, err = readADX(data)
+	} else {
+		records, err = readADI(data)
+	}
+	if err != nil {
+		return Log{}, err
+	}
+
+	l := header
+	l.QSOs = make([]QSO, 0, len(records))
+	for i, rec := range records {
+		q, err := rec.qso(header.CallSign)
+		if err != nil {
+			return Log{}, fmt.Errorf("record %d: %w", i+1, err)
+		}
+		l.QSOs = append(l.QSOs, q)
+	}
+
+	return l, nil
+}
+
+// readADI reads the records of an ADI file. Field names are converted to upper
+// case and the header is skipped.
+func readADI(data []byte) ([]adifRecord, error) {
+	var records []adifRecord
+	rec := make(adifRecord)
+	for {
+		start := bytes.IndexByte(data, '<')
+		if start == -1 {
+			break
+		}
+		end := bytes.IndexByte(data[start:], '>')
+		if end == -1 {
+			return nil, errors.New("unterminated data specifier")
+		}
+		spec := string(data[start+1 : start+end])
+		data = data[start+end+1:]
+
+		parts := strings.Split(spec, ":")
+		name := strings.ToUpper(strings.TrimSpace(parts[0]))
+		switch {
+		case name == "EOH":
+			// Everything before the end of the header belongs to the header.
+			rec = make(adifRecord)
+			continue
+		case name == "EOR":
+			records = append(records, rec)
+			rec = make(adifRecord)
+			continue
+		case len(parts) < 2:
+			return nil, fmt.Errorf("data specifier <%s> without length", spec)
+		}
+
+		n, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid length in data specifier <%s>", spec)
+		}
+		if n > len(data) {
+			return nil, fmt.Errorf("data of field %s is shorter than %d characters", name, n)
+		}
+		rec[name] = string(data[:n])
+		data = data[n:]
+	}
+
+	return records, nil
+}
+
+// readADX reads the records of an ADX file. Application defined fields are
+// named APP_<PROGRAMID>_<FIELDNAME> like in ADI files.
+func readADX(data []byte) ([]adifRecord, error) {
+	var (
+		records  []adifRecord
+		rec      adifRecord
+		field    string
+		value    strings.Builder
+		inRecord bool
+	)
+
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing ADX: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name := strings.ToUpper(t.Name.Local)
+			switch {
+			case name == "RECORD":
+				rec = make(adifRecord)
+				inRecord = true
+			case inRecord:
+				field = adxFieldName(name, t.Attr)
+				value.Reset()
+			}
+		case xml.CharData:
+			if field != "" {
+				value.Write(t)
+			}
+		case xml.EndElement:
+			name := strings.ToUpper(t.Name.Local)
+			switch {
+			case name == "RECORD":
+				records = append(records, rec)
+				inRecord = false
+			case field != "":
+				rec[field] = value.String()
+				field = ""
+			}
+		}
+	}
+
+	return records, nil
+}
+
+// adxFieldName returns the ADI name of an ADX record element.
+func adxFieldName(name string, attrs []xml.Attr) string {
+	attr := func(key string) string {
+		for _, a := range attrs {
+			if strings.EqualFold(a.Name.Local, key) {
+				return strings.ToUpper(a.Value)
+			}
+		}
+		return ""
+	}
+
+	switch name {
+	case "APP":
+		return "APP_" + attr("PROGRAMID") + "_" + attr("FIELDNAME")
+	case "USERDEF":
+		return attr("FIELDNAME")
+	}
+	return name
+}
+
+// qso converts the record to a QSO. The station callsign is used when the
+// record doesn't have one.
+func (rec adifRecord) qso(stationCall string) (QSO, error) {
+	var q QSO
+	var err error
+
+	if q.Frequency, err = rec.frequency(); err != nil {
+		return QSO{}, err
+	}
+
+	mode := strings.ToUpper(rec["MODE"])
+	if mode == "" {
+		return QSO{}, errors.New("missing MODE")
+	}
+	q.Mode = "DG"
+	if m, ok := adifModes[strings.ToUpper(rec["SUBMODE"])]; ok {
+		q.Mode = m
+	} else if m, ok := adifModes[mode]; ok {
+		q.Mode = m
+	}
+
+	timeOn := rec["TIME_ON"]
+	if len(timeOn) > 4 {
+		timeOn = timeOn[:4]
+	}
+	q.Timestamp, err = time.Parse("20060102 1504", rec["QSO_DATE"]+" "+timeOn)
+	if err != nil {
+		return QSO{}, fmt.Errorf("parsing QSO_DATE %q and TIME_ON %q: %w", rec["QSO_DATE"], rec["TIME_ON"], err)
+	}
+
+	q.TxInfo.Callsign = firstNonEmpty(rec["STATION_CALLSIGN"], rec["OPERATOR"], stationCall)
+	q.RxInfo.Callsign = rec["CALL"]
+	if q.RxInfo.Callsign == "" {
+		return QSO{}, errors.New("missing CALL")
+	}
+
+	// Reports that aren't RST, like the dB reports of digital modes, are
+	// left empty.
+	q.TxInfo.SignalReport, _ = NewRST(rec["RST_SENT"])
+	q.RxInfo.SignalReport, _ = NewRST(rec["RST_RCVD"])
+
+	q.TxInfo.Exchange = joinNonEmpty(rec["STX"], rec["STX_STRING"])
+	q.RxInfo.Exchange = joinNonEmpty(rec["SRX"], rec["SRX_STRING"])
+
+	return q, nil
+}
+
+// frequency returns the frequency field of the QSO line for the record.
+func (rec adifRecord) frequency() (string, error) {
+	if freq := strings.TrimSpace(rec["FREQ"]); freq != "" {
+		mhz, err := strconv.ParseFloat(freq, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid FREQ %q", freq)
+		}
+		khz := strconv.FormatFloat(math.Round(mhz*1000), 'f', -1, 64)
+		if mhz < 50 {
+			return khz, nil
+		}
+		for _, b := range adifBands {
+			if b.band == BandForFrequency(khz) {
+				return b.designator, nil
+			}
+		}
+		return khz, nil
+	}
+
+	band := strings.TrimSpace(rec["BAND"])
+	if band == "" {
+		return "", errors.New("missing FREQ and BAND")
+	}
+	for _, b := range adifBands {
+		if !strings.EqualFold(b.adif, band) {
+			continue
+		}
+		if b.designator != "" {
+			return b.designator, nil
+		}
+		for _, r := range bandRanges {
+			if r.band == b.band {
+				return strconv.FormatFloat(r.low, 'f', -1, 64), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("unknown BAND %q", band)
+}
+
+// firstNonEmpty returns the first value that isn't empty.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// joinNonEmpty joins the values that aren't empty with a space.
+func joinNonEmpty(values ...string) string {
+	var parts []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// adifVersion is the version of the ADIF specification WriteADIF follows.
+const adifVersion = "3.1.4"
+
+type adifOptions struct {
+	digitalMode    string
+	digitalSubmode string
+}
+
+// ADIFOption is used to customize the ADIF output.
+type ADIFOption func(*adifOptions)
+
+// WithDigitalMode sets the ADIF MODE and SUBMODE written for QSOs with the DG
+// mode, e.g. FT8 when all the digital QSOs of the log were made with FT8.
+// Cabrillo doesn't tell which digital mode was used, so by default they are
+// written without MODE and SUBMODE. The submode may be empty.
+func WithDigitalMode(mode, submode string) ADIFOption {
+	return func(o *adifOptions) {
+		o.digitalMode = mode
+		o.digitalSubmode = submode
+	}
+}
+
+// cabrilloADIFModes maps Cabrillo modes to ADIF modes. DG is handled by
+// WithDigitalMode.
+var cabrilloADIFModes = map[string]string{
+	"CW": "CW",
+	"PH": "SSB",
+	"FM": "FM",
+	"RY": "RTTY",
+}
+
+// WriteADIF writes the QSOs of the log as an ADI file. X-QSOs are left out.
+// Each QSO becomes a record with:
+//
+//   - CALL, QSO_DATE and TIME_ON.
+//   - BAND, and FREQ in MHz unless the QSO line has a band designator.
+//   - MODE (and SUBMODE): CW, SSB for PH, FM and RTTY for RY. DG QSOs only
+//     have them when set with WithDigitalMode.
+//   - STATION_CALLSIGN, RST_SENT and RST_RCVD.
+//   - CONTEST_ID from the CONTEST field of the log.
+//   - STX and SRX with the serial numbers when the exchanges start with a
+//     number, and STX_STRING and SRX_STRING with the rest of the exchanges.
+//
+// ParseADIF reads the records back into the same QSOs, except for the
+// transmitter, which ADIF has no field for, and DG QSOs written without
+// WithDigitalMode, which have no MODE.
+func WriteADIF(w io.Writer, l Log, opts ...ADIFOption) error {
+	opt := &adifOptions{}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	ew := &errWriter{w: w}
+	ew.printf("Generated by go-cabrillo\n")
+	writeADIFField(ew, "ADIF_VER", adifVersion)
+	writeADIFField(ew, "PROGRAMID", "go-cabrillo")
+	ew.printf("<EOH>\n")
+
+	for _, q := range l.QSOs {
+		writeADIFField(ew, "CALL", q.RxInfo.Callsign)
+		writeADIFField(ew, "QSO_DATE", q.Timestamp.Format("20060102"))
+		writeADIFField(ew, "TIME_ON", q.Timestamp.Format("1504"))
+
+		band := q.Band()
+		for _, b := range adifBands {
+			if b.band == band {
+				writeADIFField(ew, "BAND", b.adif)
+				break
+			}
+		}
+		if khz, err := strconv.ParseFloat(q.Frequency, 64); err == nil && !isBandDesignator(q.Frequency) {
+			writeADIFField(ew, "FREQ", strconv.FormatFloat(khz/1000, 'f', -1, 64))
+		}
+
+		mode := strings.ToUpper(q.Mode)
+		if m, ok := cabrilloADIFModes[mode]; ok {
+			writeADIFField(ew, "MODE", m)
+		} else if mode == "DG" {
+			writeADIFField(ew, "MODE", opt.digitalMode)
+			writeADIFField(ew, "SUBMODE", opt.digitalSubmode)
+		} else {
+			writeADIFField(ew, "MODE", mode)
+		}
+
+		writeADIFField(ew, "STATION_CALLSIGN", q.TxInfo.Callsign)
+		if q.TxInfo.SignalReport != (RST{}) {
+			writeADIFField(ew, "RST_SENT", q.TxInfo.SignalReport.String())
+		}
+		if q.RxInfo.SignalReport != (RST{}) {
+			writeADIFField(ew, "RST_RCVD", q.RxInfo.SignalReport.String())
+		}
+
+		writeADIFField(ew, "CONTEST_ID", l.Contest)
+		serial, rest := splitSerial(q.TxInfo.Exchange)
+		writeADIFField(ew, "STX", serial)
+		writeADIFField(ew, "STX_STRING", rest)
+		serial, rest = splitSerial(q.RxInfo.Exchange)
+		writeADIFField(ew, "SRX", serial)
+		writeADIFField(ew, "SRX_STRING", rest)
+
+		ew.printf("<EOR>\n")
+	}
+
+	return ew.err
+}
+
+// writeADIFField writes a field of an ADI file. Empty fields are left out.
+func writeADIFField(ew *errWriter, name, value string) {
+	if value == "" {
+		return
+	}
+	ew.printf("<%s:%d>%s ", name, len(value), value)
+}
+
+// isBandDesignator returns true if freq is one of the designators used in
+// place of a frequency for 50 MHz and up. Some of them, like "144", are
+// numbers.
+func isBandDesignator(freq string) bool {
+	_, ok := bandDesignators[strings.ToUpper(strings.TrimSpace(freq))]
+	return ok
+}
+
+// splitSerial splits an exchange starting with a serial number into the serial
+// number and the rest of the exchange. Exchanges without a serial number are
+// returned as the rest.
+func splitSerial(exchange string) (string, string) {
+	fields := strings.Fields(exchange)
+	if len(fields) == 0 || !isDigits(fields[0]) {
+		return "", strings.Join(fields, " ")
+	}
+	return fields[0], strings.Join(fields[1:], " ")
+}
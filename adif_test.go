@@ -0,0 +1,142 @@
+package cabrillo
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseADIF(t *testing.T) {
+	header := Log{
+		CallSign: "N8BJQ",
+		Contest:  "CQ-WPX-CW",
+	}
+
+	expected := []QSO{
+		{
+			Frequency: "14025",
+			Mode:      "CW",
+			Timestamp: time.Date(2023, time.May, 27, 0, 1, 0, 0, time.UTC),
+			TxInfo:    Info{Callsign: "N8BJQ", SignalReport: RST{5, 9, 9}, Exchange: "1"},
+			RxInfo:    Info{Callsign: "DL1ABC", SignalReport: RST{5, 9, 9}, Exchange: "16"},
+		},
+		{
+			Frequency: "7000",
+			Mode:      "PH",
+			Timestamp: time.Date(2023, time.May, 27, 0, 3, 0, 0, time.UTC),
+			TxInfo:    Info{Callsign: "N8BJQ", SignalReport: RST{5, 9, 0}, Exchange: "2"},
+			RxInfo:    Info{Callsign: "EA2TT", SignalReport: RST{5, 9, 0}, Exchange: "14"},
+		},
+		{
+			Frequency: "144",
+			Mode:      "DG",
+			Timestamp: time.Date(2023, time.May, 27, 0, 10, 0, 0, time.UTC),
+			TxInfo:    Info{Callsign: "N8BJQ", Exchange: "3"},
+			RxInfo:    Info{Callsign: "W1AW", Exchange: "FN31"},
+		},
+		{
+			Frequency: "21080",
+			Mode:      "RY",
+			Timestamp: time.Date(2023, time.May, 27, 0, 12, 0, 0, time.UTC),
+			TxInfo:    Info{Callsign: "N8BJQ", SignalReport: RST{5, 9, 9}, Exchange: "4 OH"},
+			RxInfo:    Info{Callsign: "JA1XX", SignalReport: RST{5, 9, 9}, Exchange: "7 25"},
+		},
+	}
+
+	t.Run("adi", func(t *testing.T) {
+		fh, err := os.Open("testdata/contest.adi")
+		require.NoError(t, err)
+		defer fh.Close()
+
+		l, err := ParseADIF(fh, header)
+		require.NoError(t, err)
+		require.Equal(t, "CQ-WPX-CW", l.Contest)
+		require.Equal(t, expected, l.QSOs)
+	})
+
+	t.Run("adx", func(t *testing.T) {
+		fh, err := os.Open("testdata/contest.adx")
+		require.NoError(t, err)
+		defer fh.Close()
+
+		l, err := ParseADIF(fh, header)
+		require.NoError(t, err)
+		require.Equal(t, expected[:2], l.QSOs)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			input string
+			err   string
+		}{
+			{"<CALL:4>W1AW<EOR>", "record 1: missing FREQ and BAND"},
+			{"<CALL:4>W1AW<BAND:3>11m<EOR>", `record 1: unknown BAND "11m"`},
+			{"<CALL:4>W1AW<FREQ:2>14<EOR>", "record 1: missing MODE"},
+			{"<CALL:4>W1AW<FREQ:2>14<MODE:2>CW<QSO_DATE:4>2023<EOR>", `record 1: parsing QSO_DATE "2023" and TIME_ON ""`},
+			{"<CALL:10>W1AW<EOR>", "data of field CALL is shorter than 10 characters"},
+			{"<CALL>W1AW<EOR>", "data specifier <CALL> without length"},
+			{"<?xml version=\"1.0\"?><ADX><RECORDS><RECORD>", "parsing ADX: XML syntax error on line 1: unexpected EOF"},
+		}
+		for _, tt := range tests {
+			_, err := ParseADIF(strings.NewReader(tt.input), header)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.err)
+		}
+	})
+}
+
+func TestWriteADIF(t *testing.T) {
+	l := Log{
+		Contest: "CQ-WPX-CW",
+		QSOs: mustQSOs(t,
+			"QSO: 14025 CW 2023-05-27 0001 N8BJQ 599 1 DL1ABC 599 12",
+			"QSO: 144 PH 2023-05-27 0002 N8BJQ 59 2 W1AW 59 FN31",
+			"QSO: 7080 DG 2023-05-27 0003 N8BJQ 599 3 JA1XX 599 25",
+		),
+		XQSOs: mustQSOs(t,
+			"QSO: 7080 DG 2023-05-27 0004 N8BJQ 599 4 JA1XY 599 26",
+		),
+	}
+
+	t.Run("output", func(t *testing.T) {
+		var buf strings.Builder
+		require.NoError(t, WriteADIF(&buf, l, WithDigitalMode("MFSK", "FT4")))
+		require.Equal(t, `Generated by go-cabrillo
+<ADIF_VER:5>3.1.4 <PROGRAMID:11>go-cabrillo <EOH>
+<CALL:6>DL1ABC <QSO_DATE:8>20230527 <TIME_ON:4>0001 <BAND:3>20m <FREQ:6>14.025 <MODE:2>CW <STATION_CALLSIGN:5>N8BJQ <RST_SENT:3>599 <RST_RCVD:3>599 <CONTEST_ID:9>CQ-WPX-CW <STX:1>1 <SRX:2>12 <EOR>
+<CALL:4>W1AW <QSO_DATE:8>20230527 <TIME_ON:4>0002 <BAND:2>2m <MODE:3>SSB <STATION_CALLSIGN:5>N8BJQ <RST_SENT:2>59 <RST_RCVD:2>59 <CONTEST_ID:9>CQ-WPX-CW <STX:1>2 <SRX_STRING:4>FN31 <EOR>
+<CALL:5>JA1XX <QSO_DATE:8>20230527 <TIME_ON:4>0003 <BAND:3>40m <FREQ:4>7.08 <MODE:4>MFSK <SUBMODE:3>FT4 <STATION_CALLSIGN:5>N8BJQ <RST_SENT:3>599 <RST_RCVD:3>599 <CONTEST_ID:9>CQ-WPX-CW <STX:1>3 <SRX:2>25 <EOR>
+`, buf.String())
+	})
+
+	t.Run("digital mode not set", func(t *testing.T) {
+		var buf strings.Builder
+		require.NoError(t, WriteADIF(&buf, l))
+		require.Contains(t, buf.String(), "<CALL:5>JA1XX <QSO_DATE:8>20230527 <TIME_ON:4>0003 <BAND:3>40m <FREQ:4>7.08 <STATION_CALLSIGN:5>N8BJQ ")
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		fh, err := os.Open("testdata/cq-wpx-cw.log")
+		require.NoError(t, err)
+		defer fh.Close()
+
+		original, err := ParseLog(fh)
+		require.NoError(t, err)
+
+		var buf strings.Builder
+		require.NoError(t, WriteADIF(&buf, original))
+
+		converted, err := ParseADIF(strings.NewReader(buf.String()), Log{CallSign: original.CallSign})
+		require.NoError(t, err)
+		require.Equal(t, original.QSOs, converted.QSOs)
+
+		var digital strings.Builder
+		require.NoError(t, WriteADIF(&digital, l, WithDigitalMode("FT8", "")))
+		converted, err = ParseADIF(strings.NewReader(digital.String()), Log{})
+		require.NoError(t, err)
+		require.Equal(t, l.QSOs, converted.QSOs)
+	})
+}
@@ -0,0 +1,190 @@
+package cabrillo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The kinds of multipliers counted in the ARRL contests.
+const (
+	MultiplierStateProvince = "STATE-PROVINCE"
+	MultiplierSection       = "SECTION"
+)
+
+type arrlDXScorer struct {
+	opts *scoreOptions
+}
+
+// NewARRLDXScorer returns a Scorer implementing the rules of the ARRL
+// International DX contest (ARRL-DX-CW and ARRL-DX-SSB). Stations in the 48
+// contiguous US states and Canada (W/VE) work DX stations only and vice versa.
+// W/VE stations send their state or province and DX stations send their power.
+//
+// Each QSO counts 3 points. For W/VE stations each DXCC entity counts as a
+// multiplier once per band, the WAE entities like Sicily counting as part of
+// their DXCC entity. For DX stations each state, the District of
+// Columbia and each Canadian province counts as a multiplier once per band.
+// Stations may be worked once per band.
+func NewARRLDXScorer(opts ...ScorerOption) Scorer {
+	return &arrlDXScorer{opts: newScoreOptions(opts)}
+}
+
+func (s *arrlDXScorer) Score(l Log) (Score, error) {
+	own, err := s.opts.entities.Lookup(stationCallsign(l))
+	if err != nil {
+		return Score{}, fmt.Errorf("resolving entity of the entrant: %w", err)
+	}
+
+	dupes := newDupeChecker(DupePerBand)
+	mults := make(multiplierTracker)
+	return s.opts.scoreQSOs(l, func(q QSO) QSOScore {
+		return s.scoreQSO(isWVE(own), q, dupes, mults)
+	}), nil
+}
+
+func (s *arrlDXScorer) scoreQSO(ownWVE bool, q QSO, dupes *dupeChecker, mults multiplierTracker) QSOScore {
+	qs := QSOScore{QSO: q}
+
+	entity, err := s.opts.entities.Lookup(q.RxInfo.Callsign)
+	if err != nil {
+		qs.Status = QSOUnknownEntity
+		qs.Reason = err.Error()
+		return qs
+	}
+
+	if isWVE(entity) == ownWVE {
+		qs.Status = QSOInvalidExchange
+		qs.Reason = "W/VE stations may only work DX stations and vice versa"
+		return qs
+	}
+
+	exchange := strings.ToUpper(strings.TrimSpace(q.RxInfo.Exchange))
+	var m Multiplier
+	if ownWVE {
+		if !validPower(exchange) {
+			qs.Status = QSOInvalidExchange
+			qs.Reason = fmt.Sprintf("invalid power %q", q.RxInfo.Exchange)
+			return qs
+		}
+		m = Multiplier{Kind: MultiplierCountry, Band: q.Band(), Value: dxccPrefix(entity)}
+	} else {
+		if _, ok := arrlDXStatesProvinces[exchange]; !ok {
+			qs.Status = QSOInvalidExchange
+			qs.Reason = fmt.Sprintf("invalid state or province %q", q.RxInfo.Exchange)
+			return qs
+		}
+		m = Multiplier{Kind: MultiplierStateProvince, Band: q.Band(), Value: exchange}
+	}
+
+	if dupes.check(q) {
+		qs.Status = QSODupe
+		qs.Reason = "dupe"
+		return qs
+	}
+
+	qs.Status = QSOValid
+	qs.Points = 3
+	if mults.claim(m) {
+		qs.Multipliers = append(qs.Multipliers, m)
+	}
+
+	return qs
+}
+
+// isWVE returns true if the entity is one of the W/VE entities of the ARRL DX
+// contest. Alaska and Hawaii are separate entities and count as DX.
+func isWVE(e Entity) bool {
+	return e.Name == "United States" || e.Name == "Canada"
+}
+
+// validPower returns true if str is a transmitter power as sent in the ARRL DX
+// contest, e.g. "100", "1TT" (with cut numbers) or "KW".
+func validPower(str string) bool {
+	if str == "K" || str == "KW" {
+		return true
+	}
+
+	str = strings.TrimSuffix(str, "W")
+	if str == "" {
+		return false
+	}
+	for _, c := range str {
+		if !strings.ContainsRune("0123456789ANOT", c) {
+			return false
+		}
+	}
+	return true
+}
+
+type sweepstakesScorer struct {
+	opts *scoreOptions
+}
+
+// NewSweepstakesScorer returns a Scorer implementing the rules of ARRL
+// Sweepstakes (ARRL-SS-CW and ARRL-SS-SSB). The exchange is a serial number,
+// precedence, check and section. The callsign is part of the exchange as well,
+// but it is already in the callsign column of the QSO line. Logs must be parsed
+// with WithExchangeFields(4) and WithoutSignalReport().
+//
+// Each QSO counts 2 points and each ARRL and RAC section counts as a multiplier
+// once. Stations may be worked once regardless of band.
+func NewSweepstakesScorer(opts ...ScorerOption) Scorer {
+	return &sweepstakesScorer{opts: newScoreOptions(opts)}
+}
+
+func (s *sweepstakesScorer) Score(l Log) (Score, error) {
+	dupes := newDupeChecker(DupePerContest)
+	mults := make(multiplierTracker)
+	return s.opts.scoreQSOs(l, func(q QSO) QSOScore {
+		qs := QSOScore{QSO: q}
+
+		section, err := parseSweepstakesExchange(q.RxInfo.Exchange)
+		switch {
+		case err != nil:
+			qs.Status = QSOInvalidExchange
+			qs.Reason = err.Error()
+		case dupes.check(q):
+			qs.Status = QSODupe
+			qs.Reason = "dupe"
+		default:
+			qs.Status = QSOValid
+			qs.Points = 2
+			if m := (Multiplier{Kind: MultiplierSection, Value: section}); mults.claim(m) {
+				qs.Multipliers = append(qs.Multipliers, m)
+			}
+		}
+
+		return qs
+	}), nil
+}
+
+// sweepstakesPrecedences are the valid precedences in ARRL Sweepstakes.
+const sweepstakesPrecedences = "QABUMS"
+
+// parseSweepstakesExchange validates a received Sweepstakes exchange ("123 A
+// 72 CT") and returns the section.
+func parseSweepstakesExchange(exchange string) (string, error) {
+	fields := strings.Fields(strings.ToUpper(exchange))
+	if len(fields) != 4 {
+		return "", fmt.Errorf("expected serial number, precedence, check and section, got %q", exchange)
+	}
+
+	if n, err := strconv.Atoi(fields[0]); err != nil || n < 1 {
+		return "", fmt.Errorf("invalid serial number %q", fields[0])
+	}
+
+	if len(fields[1]) != 1 || !strings.Contains(sweepstakesPrecedences, fields[1]) {
+		return "", fmt.Errorf("invalid precedence %q", fields[1])
+	}
+
+	if len(fields[2]) != 2 || !isDigits(fields[2]) {
+		return "", fmt.Errorf("invalid check %q", fields[2])
+	}
+
+	if !isARRLSection(fields[3]) {
+		return "", fmt.Errorf("unknown section %q", fields[3])
+	}
+
+	return fields[3], nil
+}
@@ -0,0 +1,108 @@
+package cabrillo
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestARRLDXScorer(t *testing.T) {
+	t.Run("W/VE", func(t *testing.T) {
+		l := Log{
+			CallSign: "K1IR",
+			QSOs: []QSO{
+				{Frequency: "7030", Mode: "CW", RxInfo: Info{Callsign: "DL1ABC", Exchange: "100"}},
+				{Frequency: "7030", Mode: "CW", RxInfo: Info{Callsign: "DK0MM", Exchange: "KW"}},
+				{Frequency: "7030", Mode: "CW", RxInfo: Info{Callsign: "KH6ABC", Exchange: "5TT"}},
+				{Frequency: "14030", Mode: "CW", RxInfo: Info{Callsign: "DL1ABC", Exchange: "100"}},
+				{Frequency: "14030", Mode: "CW", RxInfo: Info{Callsign: "DL1ABC", Exchange: "100"}},
+				{Frequency: "14030", Mode: "CW", RxInfo: Info{Callsign: "VE3AQ", Exchange: "ON"}},
+				{Frequency: "14030", Mode: "CW", RxInfo: Info{Callsign: "G3ABC", Exchange: "599"}},
+				{Frequency: "14030", Mode: "CW", RxInfo: Info{Callsign: "F5ABC", Exchange: "CA"}},
+				{Frequency: "21030", Mode: "CW", RxInfo: Info{Callsign: "I2ABC", Exchange: "100"}},
+				{Frequency: "21030", Mode: "CW", RxInfo: Info{Callsign: "IT9ABC", Exchange: "100"}},
+			},
+		}
+
+		s, err := ScorerFor("ARRL-DX-CW")
+		require.NoError(t, err)
+
+		score, err := s.Score(l)
+		require.NoError(t, err)
+
+		require.Equal(t, 7, score.Count(QSOValid))
+		require.Equal(t, 1, score.Count(QSODupe))
+		require.Equal(t, 2, score.Count(QSOInvalidExchange))
+		require.Equal(t, 21, score.Points)
+		// DL and KH6 on 40M, DL and G on 20M, I on 15M as Sicily isn't a DXCC
+		// entity.
+		require.Equal(t, 5, score.Multipliers)
+		require.Equal(t, 105, score.Total)
+	})
+
+	t.Run("DX", func(t *testing.T) {
+		l := Log{
+			CallSign: "DL1ABC",
+			QSOs: []QSO{
+				{Frequency: "7030", Mode: "CW", RxInfo: Info{Callsign: "K1IR", Exchange: "MA"}},
+				{Frequency: "7030", Mode: "CW", RxInfo: Info{Callsign: "VE3AQ", Exchange: "ON"}},
+				{Frequency: "7030", Mode: "CW", RxInfo: Info{Callsign: "W1AW", Exchange: "ma"}},
+				{Frequency: "7030", Mode: "CW", RxInfo: Info{Callsign: "K9ZO", Exchange: "XX"}},
+				{Frequency: "7030", Mode: "CW", RxInfo: Info{Callsign: "G3ABC", Exchange: "100"}},
+			},
+		}
+
+		score, err := NewARRLDXScorer().Score(l)
+		require.NoError(t, err)
+
+		require.Equal(t, 3, score.Count(QSOValid))
+		require.Equal(t, 2, score.Count(QSOInvalidExchange))
+		require.Equal(t, 9, score.Points)
+		require.Equal(t, 2, score.Multipliers)
+	})
+}
+
+func TestSweepstakesScorer(t *testing.T) {
+	fh, err := os.Open("testdata/arrl-ss-cw.log")
+	require.NoError(t, err)
+	defer fh.Close()
+
+	l, err := ParseLog(fh, WithExchangeFields(4), WithoutSignalReport())
+	require.NoError(t, err)
+	require.Len(t, l.QSOs, 6)
+	require.Equal(t, "K9ZO", l.QSOs[0].RxInfo.Callsign)
+	require.Equal(t, "2 A 69 IL", l.QSOs[0].RxInfo.Exchange)
+	require.Equal(t, "1 B 74 STX", l.QSOs[0].TxInfo.Exchange)
+
+	s, err := ScorerFor(l.Contest)
+	require.NoError(t, err)
+
+	score, err := s.Score(l)
+	require.NoError(t, err)
+
+	statuses := make([]QSOStatus, 0, len(score.QSOs))
+	for _, qs := range score.QSOs {
+		statuses = append(statuses, qs.Status)
+	}
+	require.Equal(t, []QSOStatus{
+		QSOValid,
+		QSOValid,
+		QSODupe,
+		QSOValid,
+		QSOInvalidExchange,
+		QSOInvalidExchange,
+	}, statuses)
+	require.Contains(t, score.QSOs[4].Reason, "precedence")
+	require.Contains(t, score.QSOs[5].Reason, "section")
+
+	require.Equal(t, 6, score.Points)
+	require.Equal(t, 3, score.Multipliers)
+	require.Equal(t, 18, score.Total)
+}
+
+func TestARRLSections(t *testing.T) {
+	require.Len(t, ARRLSections(), 85)
+	require.True(t, isARRLSection("ema"))
+	require.False(t, isARRLSection("MA"))
+}
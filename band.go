@@ -0,0 +1,118 @@
+package cabrillo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Band names as used by the CATEGORY-BAND field.
+const (
+	Band160M  = "160M"
+	Band80M   = "80M"
+	Band60M   = "60M"
+	Band40M   = "40M"
+	Band30M   = "30M"
+	Band20M   = "20M"
+	Band17M   = "17M"
+	Band15M   = "15M"
+	Band12M   = "12M"
+	Band10M   = "10M"
+	Band6M    = "6M"
+	Band4M    = "4M"
+	Band2M    = "2M"
+	Band222   = "222"
+	Band432   = "432"
+	Band902   = "902"
+	Band1200  = "1.2G"
+	Band2300  = "2.3G"
+	Band3400  = "3.4G"
+	Band5700  = "5.7G"
+	Band10G   = "10G"
+	Band24G   = "24G"
+	Band47G   = "47G"
+	Band75G   = "75G"
+	Band123G  = "123G"
+	Band134G  = "134G"
+	Band241G  = "241G"
+	BandLight = "Light"
+)
+
+// bandRange maps a range of frequencies in kHz to a band.
+type bandRange struct {
+	low  float64
+	high float64
+	band string
+}
+
+var bandRanges = []bandRange{
+	{1800, 2000, Band160M},
+	{3500, 4000, Band80M},
+	{5330, 5410, Band60M},
+	{7000, 7300, Band40M},
+	{10100, 10150, Band30M},
+	{14000, 14350, Band20M},
+	{18068, 18168, Band17M},
+	{21000, 21450, Band15M},
+	{24890, 24990, Band12M},
+	{28000, 29700, Band10M},
+	{50000, 54000, Band6M},
+	{70000, 71000, Band4M},
+	{144000, 148000, Band2M},
+	{222000, 225000, Band222},
+	{420000, 450000, Band432},
+	{902000, 928000, Band902},
+	{1240000, 1300000, Band1200},
+}
+
+// bandDesignators maps the frequency designators the specification allows in
+// place of a frequency for 50 MHz and up to a band.
+var bandDesignators = map[string]string{
+	"50":    Band6M,
+	"70":    Band4M,
+	"144":   Band2M,
+	"222":   Band222,
+	"432":   Band432,
+	"902":   Band902,
+	"1.2G":  Band1200,
+	"2.3G":  Band2300,
+	"3.4G":  Band3400,
+	"5.7G":  Band5700,
+	"10G":   Band10G,
+	"24G":   Band24G,
+	"47G":   Band47G,
+	"75G":   Band75G,
+	"122G":  Band123G,
+	"123G":  Band123G,
+	"134G":  Band134G,
+	"241G":  Band241G,
+	"LIGHT": BandLight,
+}
+
+// BandForFrequency returns the band for the frequency field of a QSO line. The
+// field is either a frequency in kHz or one of the designators used for 50 MHz
+// and up. An empty string is returned if the band can't be determined.
+func BandForFrequency(freq string) string {
+	freq = strings.ToUpper(strings.TrimSpace(freq))
+	if band, ok := bandDesignators[freq]; ok {
+		return band
+	}
+
+	khz, err := strconv.ParseFloat(freq, 64)
+	if err != nil {
+		return ""
+	}
+
+	for _, r := range bandRanges {
+		if khz >= r.low && khz <= r.high {
+			return r.band
+		}
+	}
+
+	return ""
+}
+
+// Band returns the band the QSO was made on or an empty string if the band
+// can't be determined from the frequency.
+func (q QSO) Band() string {
+	return BandForFrequency(q.Frequency)
+}
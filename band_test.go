@@ -0,0 +1,34 @@
+package cabrillo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBandForFrequency(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1830", Band160M},
+		{" 3799", Band80M},
+		{"7030", Band40M},
+		{"14256", Band20M},
+		{"21250", Band15M},
+		{"28530", Band10M},
+		{"50125", Band6M},
+		{"50", Band6M},
+		{"144", Band2M},
+		{"1.2G", Band1200},
+		{"light", BandLight},
+		{"12345", ""},
+		{"abc", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			require.Equal(t, tt.expected, BandForFrequency(tt.input))
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package cabrillo
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// RuleQSY is the rule raising issues for QSOs made on the frequency of the
+// previous QSOs when the contest requires moving.
+const RuleQSY = "QSY"
+
+// BandChange is a transmitter changing bands.
+type BandChange struct {
+	// QSO is the index in Log.QSOs of the first QSO on the new band.
+	QSO         int
+	Transmitter int
+	From        string
+	To          string
+	Time        time.Time
+}
+
+// BandChanges returns the band changes of each transmitter of the log in the
+// order of the QSOs. The QSOs must be in chronological order.
+func BandChanges(l Log) []BandChange {
+	var changes []BandChange
+	band := make(map[int]string)
+	for i, q := range l.QSOs {
+		b := q.Band()
+		if prev, seen := band[q.Transmitter]; seen && prev != b {
+			changes = append(changes, BandChange{
+				QSO:         i,
+				Transmitter: q.Transmitter,
+				From:        prev,
+				To:          b,
+				Time:        q.Timestamp,
+			})
+		}
+		band[q.Transmitter] = b
+	}
+	return changes
+}
+
+// HourlyBandChanges is the number of band changes of a transmitter during a
+// clock hour.
+type HourlyBandChanges struct {
+	Transmitter int
+	Hour        time.Time
+	Changes     int
+}
+
+// CountBandChanges counts the band changes per transmitter and clock hour.
+// The counts are ordered by transmitter and hour. Hours without band changes
+// are left out.
+func CountBandChanges(changes []BandChange) []HourlyBandChanges {
+	type key struct {
+		transmitter int
+		hour        time.Time
+	}
+	counts := make(map[key]int)
+	for _, c := range changes {
+		counts[key{c.Transmitter, c.Time.Truncate(time.Hour)}]++
+	}
+
+	result := make([]HourlyBandChanges, 0, len(counts))
+	for k, n := range counts {
+		result = append(result, HourlyBandChanges{Transmitter: k.transmitter, Hour: k.hour, Changes: n})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Transmitter != result[j].Transmitter {
+			return result[i].Transmitter < result[j].Transmitter
+		}
+		return result[i].Hour.Before(result[j].Hour)
+	})
+
+	return result
+}
+
+// QSYRule limits how a transmitter may change bands and frequencies. Each
+// transmitter is checked separately. Zero values disable the limits.
+type QSYRule struct {
+	// MinTimeOnBand is how long a transmitter must remain on a band after
+	// changing bands, as in the 10 minute rule of multi-single categories.
+	// Violations are reported as RuleTenMinute.
+	MinTimeOnBand time.Duration
+	// MaxChangesPerHour is the number of band changes allowed per clock hour.
+	// Violations are reported as RuleBandChanges.
+	MaxChangesPerHour int
+	// MaxQSOsPerFrequency is the number of consecutive QSOs allowed on the same
+	// frequency before moving. Violations are reported as RuleQSY.
+	MaxQSOsPerFrequency int
+	// MinQSY is how far in kHz a transmitter must move for the frequency to
+	// count as a different one. The distance is measured from both the
+	// previous QSO and the first QSO on the frequency, so moving back and
+	// forth by a little more than MinQSY doesn't count as moving.
+	MinQSY float64
+}
+
+// The QSY rules of common contest categories.
+var (
+	// MultiSingleQSYRule is the 10 minute rule of multi-single categories.
+	MultiSingleQSYRule = QSYRule{MinTimeOnBand: 10 * time.Minute}
+	// MultiTwoQSYRule is the limit of 8 band changes per clock hour for each
+	// transmitter of multi-two categories.
+	MultiTwoQSYRule = QSYRule{MaxChangesPerHour: 8}
+	// SprintCWQSYRule is the QSY rule of the NA Sprint CW. A station calling
+	// CQ must move at least 1 kHz after working a station, while the station
+	// that answered may keep the frequency. So no more than two consecutive
+	// QSOs can be made on the same frequency.
+	SprintCWQSYRule = QSYRule{MaxQSOsPerFrequency: 2, MinQSY: 1}
+	// SprintSSBQSYRule is the QSY rule of the NA Sprint SSB, where stations
+	// must move at least 5 kHz.
+	SprintSSBQSYRule = QSYRule{MaxQSOsPerFrequency: 2, MinQSY: 5}
+)
+
+// QSYCheck returns a Check that reports QSOs violating the QSY rule. The QSOs
+// must be in chronological order.
+func QSYCheck(rule QSYRule) Check {
+	return rule.check
+}
+
+// qsyState is what QSYRule.check tracks for each transmitter.
+type qsyState struct {
+	band      string
+	arrived   time.Time
+	hour      time.Time
+	changes   int
+	start     float64
+	frequency float64
+	sameFreq  int
+}
+
+func (r QSYRule) check(l Log) []Issue {
+	const format = "2006-01-02 1504"
+
+	var issues []Issue
+	states := make(map[int]*qsyState)
+	for i, q := range l.QSOs {
+		tx := q.Transmitter
+		b := q.Band()
+		freq, err := strconv.ParseFloat(q.Frequency, 64)
+		if err != nil {
+			// Band designators like "50" carry no usable frequency.
+			freq = math.NaN()
+		}
+
+		st, seen := states[tx]
+		if !seen {
+			states[tx] = &qsyState{
+				band:      b,
+				arrived:   q.Timestamp,
+				hour:      q.Timestamp.Truncate(time.Hour),
+				start:     freq,
+				frequency: freq,
+				sameFreq:  1,
+			}
+			continue
+		}
+
+		if h := q.Timestamp.Truncate(time.Hour); !h.Equal(st.hour) {
+			st.hour = h
+			st.changes = 0
+		}
+
+		if b != st.band {
+			if d := q.Timestamp.Sub(st.arrived); r.MinTimeOnBand > 0 && d < r.MinTimeOnBand {
+				issues = append(issues, Issue{
+					QSO:      i,
+					Severity: SeverityError,
+					Rule:     RuleTenMinute,
+					Message:  fmt.Sprintf("transmitter %d changed from %s to %s %d minutes after moving to %s at %s", tx, st.band, b, d/time.Minute, st.band, st.arrived.Format(format)),
+				})
+			}
+
+			st.changes++
+			if r.MaxChangesPerHour > 0 && st.changes > r.MaxChangesPerHour {
+				issues = append(issues, Issue{
+					QSO:      i,
+					Severity: SeverityError,
+					Rule:     RuleBandChanges,
+					Message:  fmt.Sprintf("transmitter %d changed bands %d times in the hour starting at %s, only %d changes are allowed", tx, st.changes, st.hour.Format(format), r.MaxChangesPerHour),
+				})
+			}
+
+			st.band = b
+			st.arrived = q.Timestamp
+		}
+
+		if r.sameFrequency(freq, st.start) || r.sameFrequency(freq, st.frequency) {
+			st.sameFreq++
+		} else {
+			st.start = freq
+			st.sameFreq = 1
+		}
+		st.frequency = freq
+
+		if r.MaxQSOsPerFrequency > 0 && st.sameFreq > r.MaxQSOsPerFrequency {
+			issues = append(issues, Issue{
+				QSO:      i,
+				Severity: SeverityError,
+				Rule:     RuleQSY,
+				Message:  fmt.Sprintf("transmitter %d made %d consecutive QSOs on %s kHz without moving %g kHz", tx, st.sameFreq, q.Frequency, r.MinQSY),
+			})
+		}
+	}
+
+	return issues
+}
+
+// sameFrequency returns true if the frequencies in kHz are less than MinQSY
+// apart.
+func (r QSYRule) sameFrequency(a, b float64) bool {
+	return a == b || math.Abs(a-b) < r.MinQSY
+}
@@ -0,0 +1,90 @@
+package cabrillo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBandChanges(t *testing.T) {
+	l := Log{
+		QSOs: mustQSOs(t,
+			"QSO: 14030 CW 2017-11-25 0000 K1IR 599 5 SQ9E 599 15 0",
+			"QSO: 7030 CW 2017-11-25 0010 K1IR 599 5 EA2TT 599 14 0",
+			"QSO: 21030 CW 2017-11-25 0015 K1IR 599 5 HA3LN 599 15 1",
+			"QSO: 7030 CW 2017-11-25 0020 K1IR 599 5 HA9A 599 15 0",
+			"QSO: 14030 CW 2017-11-25 0050 K1IR 599 5 SP6CJK 599 15 1",
+			"QSO: 21030 CW 2017-11-25 0110 K1IR 599 5 DL1ABC 599 14 0",
+		),
+	}
+
+	changes := BandChanges(l)
+	at := func(hhmm string) time.Time {
+		ts, err := time.Parse("2006-01-02 1504", "2017-11-25 "+hhmm)
+		require.NoError(t, err)
+		return ts
+	}
+	require.Equal(t, []BandChange{
+		{QSO: 1, Transmitter: 0, From: Band20M, To: Band40M, Time: at("0010")},
+		{QSO: 4, Transmitter: 1, From: Band15M, To: Band20M, Time: at("0050")},
+		{QSO: 5, Transmitter: 0, From: Band40M, To: Band15M, Time: at("0110")},
+	}, changes)
+
+	require.Equal(t, []HourlyBandChanges{
+		{Transmitter: 0, Hour: at("0000"), Changes: 1},
+		{Transmitter: 0, Hour: at("0100"), Changes: 1},
+		{Transmitter: 1, Hour: at("0000"), Changes: 1},
+	}, CountBandChanges(changes))
+}
+
+func TestQSYCheck(t *testing.T) {
+	l := Log{
+		QSOs: mustQSOs(t,
+			// Answered a CQ on 7030 and worked a station on the frequency.
+			"QSO: 7030 CW 2017-11-25 0000 K1IR 599 5 SQ9E 599 15",
+			"QSO: 7030 CW 2017-11-25 0001 K1IR 599 5 EA2TT 599 14",
+			// Should have moved.
+			"QSO: 7030 CW 2017-11-25 0002 K1IR 599 5 HA3LN 599 15",
+			"QSO: 7032 CW 2017-11-25 0003 K1IR 599 5 HA9A 599 15",
+			"QSO: 7033 CW 2017-11-25 0004 K1IR 599 5 SP6CJK 599 15",
+			"QSO: 14033 CW 2017-11-25 0005 K1IR 599 5 DL1ABC 599 14",
+		),
+	}
+
+	t.Run("sprint CW", func(t *testing.T) {
+		require.Equal(t, []Issue{
+			{QSO: 2, Severity: SeverityError, Rule: RuleQSY, Message: "transmitter 0 made 3 consecutive QSOs on 7030 kHz without moving 1 kHz"},
+		}, Validate(l, QSYCheck(SprintCWQSYRule)))
+	})
+
+	t.Run("sprint SSB", func(t *testing.T) {
+		require.Equal(t, []Issue{
+			{QSO: 2, Severity: SeverityError, Rule: RuleQSY, Message: "transmitter 0 made 3 consecutive QSOs on 7030 kHz without moving 5 kHz"},
+			{QSO: 3, Severity: SeverityError, Rule: RuleQSY, Message: "transmitter 0 made 4 consecutive QSOs on 7032 kHz without moving 5 kHz"},
+			{QSO: 4, Severity: SeverityError, Rule: RuleQSY, Message: "transmitter 0 made 5 consecutive QSOs on 7033 kHz without moving 5 kHz"},
+		}, Validate(l, QSYCheck(SprintSSBQSYRule)))
+	})
+
+	t.Run("back to the frequency", func(t *testing.T) {
+		l := Log{
+			QSOs: mustQSOs(t,
+				"QSO: 7030 CW 2017-11-25 0000 K1IR 599 5 SQ9E 599 15",
+				"QSO: 7034 CW 2017-11-25 0001 K1IR 599 5 EA2TT 599 14",
+				// 5 kHz from the previous QSO, but not from 7030.
+				"QSO: 7029 CW 2017-11-25 0002 K1IR 599 5 HA3LN 599 15",
+				"QSO: 7035 CW 2017-11-25 0003 K1IR 599 5 HA9A 599 15",
+			),
+		}
+		require.Equal(t, []Issue{
+			{QSO: 2, Severity: SeverityError, Rule: RuleQSY, Message: "transmitter 0 made 3 consecutive QSOs on 7029 kHz without moving 5 kHz"},
+		}, Validate(l, QSYCheck(SprintSSBQSYRule)))
+	})
+
+	t.Run("minimum time on band", func(t *testing.T) {
+		rule := QSYRule{MinTimeOnBand: 10 * time.Minute}
+		require.Equal(t, []Issue{
+			{QSO: 5, Severity: SeverityError, Rule: RuleTenMinute, Message: "transmitter 0 changed from 40M to 20M 5 minutes after moving to 40M at 2017-11-25 0000"},
+		}, Validate(l, QSYCheck(rule)))
+	})
+}
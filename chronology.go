@@ -0,0 +1,106 @@
+package cabrillo
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// The rules checked by ChronologyCheck.
+const (
+	RuleOutOfOrder = "OUT-OF-ORDER"
+	RuleClockJump  = "CLOCK-JUMP"
+	RuleFutureQSO  = "FUTURE-QSO"
+)
+
+type chronologyOptions struct {
+	maxBackwardJump time.Duration
+	now             time.Time
+}
+
+// ChronologyOption is used to customize the chronology check.
+type ChronologyOption func(*chronologyOptions)
+
+// WithMaxBackwardJump sets how far a QSO can be before the previous QSO of the
+// same transmitter before it's reported as a clock jump rather than a QSO out
+// of order. Defaults to 10 minutes.
+func WithMaxBackwardJump(d time.Duration) ChronologyOption {
+	return func(o *chronologyOptions) {
+		o.maxBackwardJump = d
+	}
+}
+
+// WithReferenceTime sets the time QSOs are reported as being in the future
+// after, typically the time the log file was written. Defaults to the current
+// time.
+func WithReferenceTime(t time.Time) ChronologyOption {
+	return func(o *chronologyOptions) {
+		o.now = t
+	}
+}
+
+// ChronologyCheck returns a Check that reports QSOs logged before the previous
+// QSO of the same transmitter and QSOs dated in the future. Small backward
+// steps are warnings as they are common when logs of several computers are
+// merged, while large ones point at a clock that was set wrong.
+func ChronologyCheck(opts ...ChronologyOption) Check {
+	opt := &chronologyOptions{
+		maxBackwardJump: 10 * time.Minute,
+		now:             time.Now(),
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	return func(l Log) []Issue {
+		const format = "2006-01-02 1504"
+
+		var issues []Issue
+		previous := make(map[int]time.Time)
+		for i, q := range l.QSOs {
+			if q.Timestamp.After(opt.now) {
+				issues = append(issues, Issue{
+					QSO:      i,
+					Severity: SeverityError,
+					Rule:     RuleFutureQSO,
+					Message:  fmt.Sprintf("QSO dated after %s", opt.now.UTC().Format(format)),
+				})
+			}
+
+			prev, ok := previous[q.Transmitter]
+			previous[q.Transmitter] = q.Timestamp
+			if !ok || !q.Timestamp.Before(prev) {
+				continue
+			}
+
+			if jump := prev.Sub(q.Timestamp); jump > opt.maxBackwardJump {
+				issues = append(issues, Issue{
+					QSO:      i,
+					Severity: SeverityError,
+					Rule:     RuleClockJump,
+					Message:  fmt.Sprintf("time jumps back %s from the previous QSO of transmitter %d at %s", formatDuration(jump), q.Transmitter, prev.Format(format)),
+				})
+				continue
+			}
+
+			issues = append(issues, Issue{
+				QSO:      i,
+				Severity: SeverityWarning,
+				Rule:     RuleOutOfOrder,
+				Message:  fmt.Sprintf("QSO logged after the QSO of transmitter %d at %s", q.Transmitter, prev.Format(format)),
+			})
+		}
+		return issues
+	}
+}
+
+// SortQSOs sorts the QSOs by time and then by transmitter. QSOs with the same
+// time and transmitter keep their order.
+func SortQSOs(qsos []QSO) {
+	sort.SliceStable(qsos, func(i, j int) bool {
+		if !qsos[i].Timestamp.Equal(qsos[j].Timestamp) {
+			return qsos[i].Timestamp.Before(qsos[j].Timestamp)
+		}
+		return qsos[i].Transmitter < qsos[j].Transmitter
+	})
+}
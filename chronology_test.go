@@ -0,0 +1,52 @@
+package cabrillo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChronologyCheck(t *testing.T) {
+	l := Log{
+		QSOs: mustQSOs(t,
+			"QSO: 7030 CW 2017-11-25 2121 K1IR 599 5 SQ9E 599 15 0",
+			"QSO: 14030 CW 2017-11-25 2119 K1IR 599 5 EA2TT 599 14 1",
+			"QSO: 7030 CW 2017-11-25 2125 K1IR 599 5 HA9A 599 15 0",
+			"QSO: 7030 CW 2017-11-25 2123 K1IR 599 5 HA3LN 599 15 0",
+			"QSO: 14030 CW 2017-11-25 1900 K1IR 599 5 SP6CJK 599 15 1",
+			"QSO: 14030 CW 2017-11-25 1901 K1IR 599 5 DL1ABC 599 14 1",
+			"QSO: 7030 CW 2017-11-27 0000 K1IR 599 5 HA8A 599 15 0",
+		),
+	}
+
+	now := time.Date(2017, time.November, 26, 12, 0, 0, 0, time.UTC)
+	require.Equal(t, []Issue{
+		{QSO: 3, Severity: SeverityWarning, Rule: RuleOutOfOrder, Message: "QSO logged after the QSO of transmitter 0 at 2017-11-25 2125"},
+		{QSO: 4, Severity: SeverityError, Rule: RuleClockJump, Message: "time jumps back 2h19m from the previous QSO of transmitter 1 at 2017-11-25 2119"},
+		{QSO: 6, Severity: SeverityError, Rule: RuleFutureQSO, Message: "QSO dated after 2017-11-26 1200"},
+	}, Validate(l, ChronologyCheck(WithReferenceTime(now))))
+
+	t.Run("max backward jump", func(t *testing.T) {
+		issues := Validate(l, ChronologyCheck(WithReferenceTime(now), WithMaxBackwardJump(time.Minute)))
+		require.Len(t, issues, 3)
+		require.Equal(t, RuleClockJump, issues[0].Rule)
+	})
+}
+
+func TestSortQSOs(t *testing.T) {
+	qsos := mustQSOs(t,
+		"QSO: 7030 CW 2017-11-25 2125 K1IR 599 5 HA9A 599 15 0",
+		"QSO: 14030 CW 2017-11-25 2121 K1IR 599 5 EA2TT 599 14 1",
+		"QSO: 7030 CW 2017-11-25 2121 K1IR 599 5 SQ9E 599 15 0",
+		"QSO: 7030 CW 2017-11-25 2121 K1IR 599 5 HA3LN 599 15 0",
+	)
+
+	SortQSOs(qsos)
+
+	var calls []string
+	for _, q := range qsos {
+		calls = append(calls, q.RxInfo.Callsign)
+	}
+	require.Equal(t, []string{"SQ9E", "HA3LN", "EA2TT", "HA9A"}, calls)
+}
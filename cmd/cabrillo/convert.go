@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/jasonhancock/go-cabrillo"
+)
+
+var convertCommand = command{
+	name:        "convert",
+	usage:       "-to format [flags] [file]",
+	description: "convert a log between Cabrillo, ADIF, CSV, EDI and JSON",
+	run:         runConvert,
+}
+
+func runConvert(e *env, fs *flag.FlagSet, args []string) error {
+	lf := addLogFlags(fs)
+	from := fs.String("from", "", "format of the input: cabrillo, adif, csv, edi or json (default from the file extension, or cabrillo)")
+	to := fs.String("to", "", "format of the output: cabrillo, adif, csv, edi or json")
+	output := fs.String("o", "", "file to write the output to (default the standard output)")
+	call := fs.String("call", "", "station callsign for ADIF and CSV input, which have no header")
+	contest := fs.String("contest", "", "contest name for ADIF and CSV input, which have no header")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	files := inputs(fs.Args())
+	switch {
+	case len(files) > 1:
+		return usageError("convert takes a single file")
+	case *to == "":
+		return usageError("missing -to")
+	}
+
+	format := *from
+	if format == "" {
+		format = formatForFile(files[0])
+	}
+	if format == "" {
+		format = formatCabrillo
+	}
+
+	header := cabrillo.Log{CallSign: *call, Contest: *contest, Certificate: true}
+	l, err := e.readLogAs(files[0], format, header, lf)
+	if err != nil {
+		return err
+	}
+
+	w, err := e.createFile(*output)
+	if err != nil {
+		return err
+	}
+	if err := writeLogAs(w, l, *to, lf); err != nil {
+		w.Close()
+		return fmt.Errorf("writing %s: %w", *to, err)
+	}
+	return w.Close()
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/jasonhancock/go-cabrillo"
+)
+
+var dupesCommand = command{
+	name:        "dupes",
+	usage:       "[flags] [file...]",
+	description: "list the duplicate QSOs of logs",
+	run:         runDupes,
+}
+
+// dupeScopes maps the values of the -scope flag to dupe scopes.
+var dupeScopes = map[string]cabrillo.DupeScope{
+	"band":      cabrillo.DupePerBand,
+	"band-mode": cabrillo.DupePerBandMode,
+	"contest":   cabrillo.DupePerContest,
+}
+
+// fileDupes are the dupes found in a file.
+type fileDupes struct {
+	File  string `json:"file"`
+	Dupes []dupe `json:"dupes"`
+}
+
+type dupe struct {
+	// Number is the 1-based number of the QSO.
+	Number int          `json:"number"`
+	QSO    cabrillo.QSO `json:"qso"`
+}
+
+func runDupes(e *env, fs *flag.FlagSet, args []string) error {
+	lf := addLogFlags(fs)
+	asJSON := fs.Bool("json", false, "write the dupes as JSON")
+	scopeName := fs.String("scope", "band", "stations may be worked once per band, band-mode or contest")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	scope, ok := dupeScopes[*scopeName]
+	if !ok {
+		return usageError(fmt.Sprintf("unknown scope %q", *scopeName))
+	}
+
+	var results []fileDupes
+	for _, name := range inputs(fs.Args()) {
+		l, err := e.readLog(name, lf)
+		if err != nil {
+			return err
+		}
+
+		result := fileDupes{File: displayName(name), Dupes: []dupe{}}
+		for _, i := range cabrillo.FindDupes(l.QSOs, scope) {
+			result.Dupes = append(result.Dupes, dupe{Number: i + 1, QSO: l.QSOs[i]})
+			if !*asJSON {
+				fmt.Fprintf(e.stdout, "%s: QSO %d: %s\n", displayName(name), i+1, l.QSOs[i])
+			}
+		}
+		results = append(results, result)
+	}
+
+	if *asJSON {
+		return writeJSON(e.stdout, results)
+	}
+	return nil
+}
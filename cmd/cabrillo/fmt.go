@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jasonhancock/go-cabrillo"
+)
+
+var fmtCommand = command{
+	name:        "fmt",
+	usage:       "[flags] [file...]",
+	description: "rewrite logs in the canonical format",
+	run:         runFmt,
+}
+
+func runFmt(e *env, fs *flag.FlagSet, args []string) error {
+	lf := addLogFlags(fs)
+	write := fs.Bool("w", false, "write the result to the files instead of the standard output")
+	list := fs.Bool("l", false, "list the files whose formatting differs from the canonical format instead of writing them")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	for _, name := range inputs(fs.Args()) {
+		r, err := e.open(name)
+		if err != nil {
+			return err
+		}
+		data, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		if err := cabrillo.FormatLog(&buf, bytes.NewReader(data), lf.parserOptions(data)...); err != nil {
+			return fmt.Errorf("%s: %w", displayName(name), err)
+		}
+		formatted := buf.Bytes()
+
+		switch {
+		case *list:
+			if !bytes.Equal(data, formatted) {
+				fmt.Fprintln(e.stdout, displayName(name))
+			}
+		case *write && name != "-":
+			if bytes.Equal(data, formatted) {
+				continue
+			}
+			info, err := os.Stat(name)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(name, formatted, info.Mode()); err != nil {
+				return err
+			}
+		default:
+			if _, err := e.stdout.Write(formatted); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jasonhancock/go-cabrillo"
+)
+
+// The formats logs are read and written in.
+const (
+	formatCabrillo = "cabrillo"
+	formatADIF     = "adif"
+	formatCSV      = "csv"
+	formatEDI      = "edi"
+	formatJSON     = "json"
+)
+
+// formatExtensions maps file extensions to formats.
+var formatExtensions = map[string]string{
+	".log":  formatCabrillo,
+	".cbr":  formatCabrillo,
+	".adi":  formatADIF,
+	".adif": formatADIF,
+	".adx":  formatADIF,
+	".csv":  formatCSV,
+	".edi":  formatEDI,
+	".json": formatJSON,
+}
+
+// logFlags are the flags controlling how Cabrillo logs are parsed.
+type logFlags struct {
+	exchangeFields int
+	noRST          bool
+}
+
+func addLogFlags(fs *flag.FlagSet) *logFlags {
+	f := &logFlags{}
+	fs.IntVar(&f.exchangeFields, "exchange-fields", 0, "number of space delimited fields in the exchange of QSO lines (default from the first QSO line)")
+	fs.BoolVar(&f.noRST, "no-rst", false, "QSO lines have no signal reports, like in ARRL Sweepstakes")
+	return f
+}
+
+// parserOptions returns the options to parse the log. Without -exchange-fields,
+// the number of exchange fields is taken from the first QSO line of the log.
+func (f *logFlags) parserOptions(data []byte) []cabrillo.ParserOption {
+	n := f.exchangeFields
+	if n <= 0 {
+		n = detectExchangeFields(data, !f.noRST)
+	}
+	opts := []cabrillo.ParserOption{cabrillo.WithExchangeFields(n)}
+	if f.noRST {
+		opts = append(opts, cabrillo.WithoutSignalReport())
+	}
+	return opts
+}
+
+// detectExchangeFields returns the number of exchange fields of the first QSO
+// line of the log, or 1 if it has none. Both sides of a QSO have the same
+// number of fields, so a line with an odd number of fields after the date and
+// time has a transmitter column.
+func detectExchangeFields(data []byte, signalReport bool) int {
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if tag := strings.ToUpper(fields[0]); tag != "QSO:" && tag != "X-QSO:" {
+			continue
+		}
+
+		// The tag, frequency, mode, date and time come first.
+		sides := len(fields) - 5
+		if sides%2 == 1 {
+			sides--
+		}
+		n := sides/2 - 1
+		if signalReport {
+			n--
+		}
+		if n < 1 {
+			return 1
+		}
+		return n
+	}
+	return 1
+}
+
+// inputs returns the files named by the arguments, or "-" for the standard
+// input when there are none.
+func inputs(args []string) []string {
+	if len(args) == 0 {
+		return []string{"-"}
+	}
+	return args
+}
+
+// open opens the file, or returns the standard input for "-".
+func (e *env) open(name string) (io.ReadCloser, error) {
+	if name == "-" {
+		return io.NopCloser(e.stdin), nil
+	}
+	return os.Open(name)
+}
+
+// readLog reads a Cabrillo log from the file.
+func (e *env) readLog(name string, f *logFlags) (cabrillo.Log, error) {
+	return e.readLogAs(name, formatCabrillo, cabrillo.Log{}, f)
+}
+
+// readLogAs reads a log in the format from the file. The header is used for
+// the formats that don't have one.
+func (e *env) readLogAs(name, format string, header cabrillo.Log, f *logFlags) (cabrillo.Log, error) {
+	r, err := e.open(name)
+	if err != nil {
+		return cabrillo.Log{}, err
+	}
+	defer r.Close()
+
+	var l cabrillo.Log
+	switch format {
+	case formatCabrillo:
+		var data []byte
+		if data, err = io.ReadAll(r); err == nil {
+			l, err = cabrillo.ParseLog(bytes.NewReader(data), f.parserOptions(data)...)
+		}
+	case formatADIF:
+		l, err = cabrillo.ParseADIF(r, header)
+	case formatCSV:
+		l, err = cabrillo.ReadCSV(r, header)
+	case formatEDI:
+		l, err = cabrillo.ParseEDI(r)
+	case formatJSON:
+		err = json.NewDecoder(r).Decode(&l)
+	default:
+		return cabrillo.Log{}, usageError(fmt.Sprintf("unknown format %q", format))
+	}
+	if err != nil {
+		return cabrillo.Log{}, fmt.Errorf("%s: %w", displayName(name), err)
+	}
+	return l, nil
+}
+
+// writeLogAs writes the log in the format.
+func writeLogAs(w io.Writer, l cabrillo.Log, format string, f *logFlags) error {
+	switch format {
+	case formatCabrillo:
+		return cabrillo.WriteLog(w, l)
+	case formatADIF:
+		return cabrillo.WriteADIF(w, l)
+	case formatCSV:
+		columns := f.exchangeFields
+		if columns <= 0 {
+			columns = 1
+		}
+		return cabrillo.WriteCSV(w, l, cabrillo.WithExchangeColumns(columns))
+	case formatEDI:
+		return cabrillo.WriteEDI(w, l)
+	case formatJSON:
+		return writeJSON(w, l)
+	}
+	return usageError(fmt.Sprintf("unknown format %q", format))
+}
+
+// formatForFile returns the format of a file from its extension, or an empty
+// string if it's unknown.
+func formatForFile(name string) string {
+	return formatExtensions[strings.ToLower(filepath.Ext(name))]
+}
+
+// displayName returns the name of the file for messages.
+func displayName(name string) string {
+	if name == "-" {
+		return "<stdin>"
+	}
+	return name
+}
+
+// writeJSON writes v as indented JSON.
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// createFile creates the file, or returns the standard output for "-" or an
+// empty name.
+func (e *env) createFile(name string) (io.WriteCloser, error) {
+	if name == "" || name == "-" {
+		return nopWriteCloser{e.stdout}, nil
+	}
+	return os.Create(name)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,136 @@
+// Command cabrillo validates, formats, converts and scores Cabrillo contest
+// logs.
+//
+// Usage:
+//
+//	cabrillo <command> [flags] [file...]
+//
+// The commands are:
+//
+//	validate  check logs against the specification and the contest rules
+//	fmt       rewrite logs in the canonical format
+//	stats     summarize the QSOs of logs
+//	convert   convert a log between Cabrillo, ADIF, CSV, EDI and JSON
+//	score     compute the score of logs and compare it with the claimed score
+//	dupes     list the duplicate QSOs of logs
+//	merge     merge the logs of several logging computers into one
+//	split     split a log into several logs
+//
+// Commands read the files named on the command line, or the standard input
+// when there are none or the file is "-". Run "cabrillo <command> -h" for the
+// flags of a command.
+//
+// The exit status is 0 on success, 1 when a command fails or finds errors in
+// a log, and 2 when it is used incorrectly.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// errIssues is returned by commands that ran but found errors in a log. The
+// errors have already been reported.
+var errIssues = errors.New("issues found")
+
+// env is the environment commands run in.
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// command is a subcommand of the tool.
+type command struct {
+	name        string
+	usage       string
+	description string
+	run         func(e *env, fs *flag.FlagSet, args []string) error
+}
+
+var commands = []command{
+	validateCommand,
+	fmtCommand,
+	statsCommand,
+	convertCommand,
+	scoreCommand,
+	dupesCommand,
+	mergeCommand,
+	splitCommand,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command named by the first argument and returns the exit
+// status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	e := &env{stdin: stdin, stdout: stdout, stderr: stderr}
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "-help" {
+		usage(stderr)
+		return 2
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+
+		fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+		fs.SetOutput(stderr)
+		fs.Usage = func() {
+			fmt.Fprintf(stderr, "usage: cabrillo %s %s\n\n%s\n\nflags:\n", cmd.name, cmd.usage, cmd.description)
+			fs.PrintDefaults()
+		}
+
+		err := cmd.run(e, fs, args[1:])
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			return 2
+		case errors.Is(err, errIssues):
+			return 1
+		case errors.As(err, new(usageError)):
+			fmt.Fprintf(stderr, "cabrillo %s: %v\n", cmd.name, err)
+			fs.Usage()
+			return 2
+		}
+		fmt.Fprintf(stderr, "cabrillo %s: %v\n", cmd.name, err)
+		return 1
+	}
+
+	fmt.Fprintf(stderr, "cabrillo: unknown command %q\n", args[0])
+	usage(stderr)
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "usage: cabrillo <command> [flags] [file...]\n\ncommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-9s %s\n", cmd.name, cmd.description)
+	}
+}
+
+// usageError is an error in the way a command was invoked.
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+// parseFlags parses the flags of a command. Errors have already been reported
+// by the flag set.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return flag.ErrHelp
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jasonhancock/go-cabrillo"
+	"github.com/stretchr/testify/require"
+)
+
+// runCommand runs the tool and returns the exit status and outputs.
+func runCommand(t *testing.T, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr strings.Builder
+	status := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return status, stdout.String(), stderr.String()
+}
+
+const testLog = `START-OF-LOG: 3.0
+CONTEST: CQ-WPX-CW
+CALLSIGN: N8BJQ
+CATEGORY-OPERATOR: SINGLE-OP
+QSO: 14025 CW 2023-05-27 0001 N8BJQ 599 1 DL1ABC 599 12
+QSO:  7025 CW 2023-05-27 0100 N8BJQ 599 2 W1AW 599 301
+QSO:  7025 CW 2023-05-27 0101 N8BJQ 599 3 W1AW 599 302
+END-OF-LOG:
+`
+
+func TestRun(t *testing.T) {
+	t.Run("usage", func(t *testing.T) {
+		status, _, stderr := runCommand(t, "")
+		require.Equal(t, 2, status)
+		require.Contains(t, stderr, "usage: cabrillo <command>")
+
+		status, _, stderr = runCommand(t, "", "frobnicate")
+		require.Equal(t, 2, status)
+		require.Contains(t, stderr, `unknown command "frobnicate"`)
+
+		status, _, stderr = runCommand(t, "", "stats", "-bogus")
+		require.Equal(t, 2, status)
+		require.Contains(t, stderr, "usage: cabrillo stats")
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		status, _, stderr := runCommand(t, "QSO: 14025 CW 2023-05-27 0001 N8BJQ\n", "stats")
+		require.Equal(t, 1, status)
+		require.Contains(t, stderr, "cabrillo stats: <stdin>: 0: invalid number of fields in QSO")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	status, stdout, _ := runCommand(t, testLog, "validate")
+	require.Equal(t, 0, status)
+	require.Empty(t, stdout)
+
+	status, stdout, _ = runCommand(t, "", "validate", "../../testdata/allfields.log")
+	require.Equal(t, 1, status)
+	require.Equal(t, `../../testdata/allfields.log: ERROR CATEGORY: CATEGORY-MODE: value "PH" not in possible values "CW,DIGI,FM,RTTY,SSB,MIXED"`+"\n", stdout)
+
+	status, stdout, _ = runCommand(t, strings.Replace(testLog, "2023-05-27 0101", "2023-05-29 0101", 1), "validate", "-json")
+	require.Equal(t, 1, status)
+	var results []fileIssues
+	require.NoError(t, json.Unmarshal([]byte(stdout), &results))
+	require.Equal(t, []fileIssues{{
+		File: "<stdin>",
+		Issues: []jsonIssue{{
+			QSO:      3,
+			Severity: cabrillo.SeverityError,
+			Rule:     cabrillo.RuleOutOfPeriod,
+			Message:  "QSO made after the contest ended at 2023-05-29 0000",
+		}},
+	}}, results)
+}
+
+func TestStats(t *testing.T) {
+	status, stdout, _ := runCommand(t, testLog, "stats", "-json")
+	require.Equal(t, 0, status)
+
+	var results []stats
+	require.NoError(t, json.Unmarshal([]byte(stdout), &results))
+	require.Equal(t, []stats{{
+		File:          "<stdin>",
+		Callsign:      "N8BJQ",
+		Contest:       "CQ-WPX-CW",
+		QSOs:          3,
+		Dupes:         1,
+		Unique:        2,
+		First:         "2023-05-27 0001",
+		Last:          "2023-05-27 0101",
+		OperatingTime: "1h0m0s",
+		Bands:         map[string]int{"20M": 1, "40M": 2},
+		Modes:         map[string]int{"CW": 3},
+		Transmitters:  map[int]int{0: 3},
+	}}, results)
+
+	status, stdout, _ = runCommand(t, testLog, "stats")
+	require.Equal(t, 0, status)
+	require.Contains(t, stdout, "  QSOs:             3 (1 dupes, 0 X-QSOs)\n")
+}
+
+func TestScore(t *testing.T) {
+	status, stdout, _ := runCommand(t, "", "score", "-json", "../../testdata/cq-wpx-cw.log")
+	require.Equal(t, 0, status)
+
+	var results []scoreResult
+	require.NoError(t, json.Unmarshal([]byte(stdout), &results))
+	require.Equal(t, []scoreResult{{
+		File:        "../../testdata/cq-wpx-cw.log",
+		Callsign:    "N8BJQ",
+		Contest:     "CQ-WPX-CW",
+		QSOs:        5,
+		Dupes:       1,
+		Points:      18,
+		Multipliers: 4,
+		Total:       72,
+		Claimed:     72,
+	}}, results)
+
+	status, _, stderr := runCommand(t, strings.Replace(testLog, "CQ-WPX-CW", "NO-SUCH-CONTEST", 1), "score")
+	require.Equal(t, 1, status)
+	require.Contains(t, stderr, `no scorer for contest "NO-SUCH-CONTEST"`)
+}
+
+func TestDupes(t *testing.T) {
+	status, stdout, _ := runCommand(t, testLog, "dupes")
+	require.Equal(t, 0, status)
+	require.Equal(t, "<stdin>: QSO 3: 7025 CW 2023-05-27 0101 N8BJQ 599 3 W1AW 599 302\n", stdout)
+
+	status, stdout, _ = runCommand(t, testLog, "dupes", "-scope", "band-mode", "-json")
+	require.Equal(t, 0, status)
+	var results []fileDupes
+	require.NoError(t, json.Unmarshal([]byte(stdout), &results))
+	require.Len(t, results[0].Dupes, 1)
+	require.Equal(t, 3, results[0].Dupes[0].Number)
+
+	status, _, _ = runCommand(t, testLog, "dupes", "-scope", "hour")
+	require.Equal(t, 2, status)
+}
+
+func TestConvert(t *testing.T) {
+	status, stdout, _ := runCommand(t, testLog, "convert", "-to", "json")
+	require.Equal(t, 0, status)
+
+	status, converted, _ := runCommand(t, stdout, "convert", "-from", "json", "-to", "cabrillo")
+	require.Equal(t, 0, status)
+	require.Contains(t, converted, "QSO:  7025 CW 2023-05-27 0100 N8BJQ         599 2      W1AW          599 301\n")
+
+	status, stdout, _ = runCommand(t, "", "convert", "-to", "cabrillo", "-call", "N8BJQ", "-o", "-", "../../testdata/contest.adi")
+	require.Equal(t, 0, status)
+	require.Contains(t, stdout, "QSO: 14025 CW 2023-05-27 0001 N8BJQ         599 1      -      DL1ABC        599 16     -\n")
+	require.Contains(t, stdout, "QSO:   144 DG 2023-05-27 0010 N8BJQ         -   3      -      W1AW          -   FN31   -\n")
+
+	// The converted log can be read back.
+	status, _, stderr := runCommand(t, stdout, "validate")
+	require.Equal(t, 0, status, stderr)
+
+	status, _, stderr = runCommand(t, testLog, "convert")
+	require.Equal(t, 2, status)
+	require.Contains(t, stderr, "missing -to")
+
+	status, _, stderr = runCommand(t, testLog, "convert", "-to", "edi")
+	require.Equal(t, 1, status)
+	require.Contains(t, stderr, "writing edi: QSOs on 20M and 40M")
+}
+
+func TestFmt(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "test.log")
+	input := strings.Replace(testLog, "CALLSIGN: N8BJQ\n", "CALLSIGN: N8BJQ\nARRL-SECTION: MI\nSOAPBOX: Two  spaces\n", 1)
+	require.NoError(t, os.WriteFile(name, []byte(strings.ReplaceAll(input, "\n", "\r\n")), 0600))
+
+	status, stdout, _ := runCommand(t, "", "fmt", "-l", name, "../../testdata/cq-wpx-cw.log")
+	require.Equal(t, 0, status)
+	require.Equal(t, name+"\n", stdout)
+
+	status, _, _ = runCommand(t, "", "fmt", "-w", name)
+	require.Equal(t, 0, status)
+
+	data, err := os.ReadFile(name)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "\r")
+	require.Contains(t, string(data), "\nARRL-SECTION: MI\n")
+	require.Contains(t, string(data), "\nSOAPBOX: Two  spaces\n")
+	require.Contains(t, string(data), "QSO:  7025 CW 2023-05-27 0100 N8BJQ         599 2      W1AW          599 301\n")
+
+	status, stdout, _ = runCommand(t, "", "fmt", "-l", name)
+	require.Equal(t, 0, status)
+	require.Empty(t, stdout)
+}
+
+func TestMergeSplit(t *testing.T) {
+	dir := t.TempDir()
+	run0 := filepath.Join(dir, "run.log")
+	mult := filepath.Join(dir, "mult.log")
+	require.NoError(t, os.WriteFile(run0, []byte(testLog), 0600))
+	multLog := strings.Replace(testLog, "CATEGORY-OPERATOR: SINGLE-OP\n", "CATEGORY-OPERATOR: MULTI-OP\n", 1)
+	multLog = strings.Replace(multLog, "DL1ABC 599 12", "DL2ABC 599 13", 1)
+	require.NoError(t, os.WriteFile(mult, []byte(multLog), 0600))
+
+	merged := filepath.Join(dir, "merged.log")
+	status, _, stderr := runCommand(t, "", "merge", "-o", merged, run0, mult)
+	require.Equal(t, 0, status)
+	require.Equal(t, "cabrillo merge: conflicting header CATEGORY-OPERATOR: SINGLE-OP, MULTI-OP\n"+
+		"cabrillo merge: ambiguous transmitter for QSO: 7025 CW 2023-05-27 0100 N8BJQ 599 2 W1AW 599 301 on transmitter 0 or 1\n"+
+		"cabrillo merge: ambiguous transmitter for QSO: 7025 CW 2023-05-27 0101 N8BJQ 599 3 W1AW 599 302 on transmitter 0 or 1\n", stderr)
+
+	data, err := os.ReadFile(merged)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "QSO: 14025 CW 2023-05-27 0001 N8BJQ         599 1      DL1ABC        599 12     0\nQSO: 14025 CW 2023-05-27 0001 N8BJQ         599 1      DL2ABC        599 13     1\nQSO:  7025 CW 2023-05-27 0100 N8BJQ         599 2      W1AW          599 301    0\n")
+	require.Equal(t, 4, strings.Count(string(data), "QSO:"))
+
+	status, stdout, _ := runCommand(t, "", "split", "-by", "transmitter", "-dir", dir, merged)
+	require.Equal(t, 0, status)
+	require.Equal(t, filepath.Join(dir, "N8BJQ-0.log")+"\n"+filepath.Join(dir, "N8BJQ-1.log")+"\n", stdout)
+
+	status, stdout, _ = runCommand(t, "", "split", "-by", "time", "-every", "1h", "-dir", dir, merged)
+	require.Equal(t, 0, status)
+	require.Equal(t, filepath.Join(dir, "N8BJQ-2023-05-27-0000.log")+"\n"+filepath.Join(dir, "N8BJQ-2023-05-27-0100.log")+"\n", stdout)
+
+	status, stdout, _ = runCommand(t, "", "split", "-by", "band", "-dir", dir, run0)
+	require.Equal(t, 0, status)
+	require.Equal(t, filepath.Join(dir, "N8BJQ-20M.log")+"\n"+filepath.Join(dir, "N8BJQ-40M.log")+"\n", stdout)
+	data, err = os.ReadFile(filepath.Join(dir, "N8BJQ-40M.log"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "CATEGORY-BAND: 40M\n")
+	require.Equal(t, 2, strings.Count(string(data), "QSO:"))
+
+	status, _, _ = runCommand(t, "", "split", "-by", "operator", run0)
+	require.Equal(t, 2, status)
+
+	status, _, _ = runCommand(t, "", "merge", run0)
+	require.Equal(t, 2, status)
+}
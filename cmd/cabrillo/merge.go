@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/jasonhancock/go-cabrillo"
+)
+
+var mergeCommand = command{
+	name:        "merge",
+	usage:       "[flags] file...",
+	description: "merge the logs of several logging computers into one, taking each header field from the first file that has it",
+	run:         runMerge,
+}
+
+func runMerge(e *env, fs *flag.FlagSet, args []string) error {
+	lf := addLogFlags(fs)
+	output := fs.String("o", "", "file to write the merged log to (default the standard output)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() < 2 {
+		return usageError("merge takes at least two files")
+	}
+
+	var logs []cabrillo.Log
+	for _, name := range fs.Args() {
+		l, err := e.readLog(name, lf)
+		if err != nil {
+			return err
+		}
+		logs = append(logs, l)
+	}
+
+	// Each file is a transmitter, unless the files record transmitters.
+	merged, conflicts, txConflicts := cabrillo.MergeLogs(logs...)
+	for _, c := range conflicts {
+		fmt.Fprintf(e.stderr, "cabrillo merge: conflicting header %s\n", c)
+	}
+	for _, c := range txConflicts {
+		fmt.Fprintf(e.stderr, "cabrillo merge: ambiguous transmitter for %s\n", c)
+	}
+
+	w, err := e.createFile(*output)
+	if err != nil {
+		return err
+	}
+	if err := cabrillo.WriteLog(w, merged); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
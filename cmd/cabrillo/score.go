@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/jasonhancock/go-cabrillo"
+)
+
+var scoreCommand = command{
+	name:        "score",
+	usage:       "[flags] [file...]",
+	description: "compute the score of logs and compare it with the claimed score",
+	run:         runScore,
+}
+
+// scoreResult is the score of a log.
+type scoreResult struct {
+	File        string `json:"file"`
+	Callsign    string `json:"callsign"`
+	Contest     string `json:"contest"`
+	QSOs        int    `json:"qsos"`
+	Dupes       int    `json:"dupes"`
+	Points      int    `json:"points"`
+	Multipliers int    `json:"multipliers"`
+	Total       int    `json:"total"`
+	Claimed     int    `json:"claimed"`
+	Difference  int    `json:"difference"`
+}
+
+func runScore(e *env, fs *flag.FlagSet, args []string) error {
+	lf := addLogFlags(fs)
+	asJSON := fs.Bool("json", false, "write the scores as JSON")
+	ctyFile := fs.String("cty", "", "resolve callsigns to entities with this cty.dat file instead of the built-in table")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	var opts []cabrillo.ScorerOption
+	if *ctyFile != "" {
+		r, err := e.open(*ctyFile)
+		if err != nil {
+			return err
+		}
+		db, err := cabrillo.ParseCtyDat(r)
+		r.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", *ctyFile, err)
+		}
+		opts = append(opts, cabrillo.WithEntityDB(db))
+	}
+
+	var results []scoreResult
+	for _, name := range inputs(fs.Args()) {
+		l, err := e.readLog(name, lf)
+		if err != nil {
+			return err
+		}
+
+		logOpts := opts
+		if c, err := cabrillo.LookupContest(l.Contest); err == nil && len(l.QSOs) > 0 {
+			logOpts = append(logOpts[:len(logOpts):len(logOpts)], cabrillo.WithPeriods(c.Periods(l.QSOs[0].Timestamp.Year())...))
+		}
+		s, err := cabrillo.ScorerFor(l.Contest, logOpts...)
+		if err != nil {
+			return fmt.Errorf("%s: %w", displayName(name), err)
+		}
+		v, err := cabrillo.VerifyClaimedScore(l, s)
+		if err != nil {
+			return fmt.Errorf("%s: %w", displayName(name), err)
+		}
+
+		results = append(results, scoreResult{
+			File:        displayName(name),
+			Callsign:    l.CallSign,
+			Contest:     l.Contest,
+			QSOs:        v.Score.Count(cabrillo.QSOValid),
+			Dupes:       len(v.Dupes),
+			Points:      v.Score.Points,
+			Multipliers: v.Score.Multipliers,
+			Total:       v.Score.Total,
+			Claimed:     v.Claimed,
+			Difference:  v.Difference,
+		})
+		if !*asJSON {
+			fmt.Fprintf(e.stdout, "%s: %s %s: %s", displayName(name), l.CallSign, l.Contest, v)
+		}
+	}
+
+	if *asJSON {
+		return writeJSON(e.stdout, results)
+	}
+	return nil
+}
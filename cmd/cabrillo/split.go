@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jasonhancock/go-cabrillo"
+)
+
+var splitCommand = command{
+	name:        "split",
+	usage:       "-by band|transmitter|time [flags] [file]",
+	description: "split a log into several logs",
+	run:         runSplit,
+}
+
+func runSplit(e *env, fs *flag.FlagSet, args []string) error {
+	lf := addLogFlags(fs)
+	by := fs.String("by", "", "split the log by band, transmitter or time")
+	every := fs.Duration("every", 24*time.Hour, "length of the periods to split the log into with -by time, starting at 0000Z of the first QSO")
+	dir := fs.String("dir", ".", "directory to write the logs to, named after the callsign and the band, transmitter or period")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	files := inputs(fs.Args())
+	if len(files) > 1 {
+		return usageError("split takes a single file")
+	}
+	switch *by {
+	case "band", "transmitter", "time":
+	default:
+		return usageError(fmt.Sprintf("unknown -by %q", *by))
+	}
+	if *every <= 0 {
+		return usageError("-every must be positive")
+	}
+
+	l, err := e.readLog(files[0], lf)
+	if err != nil {
+		return err
+	}
+
+	var parts []cabrillo.LogPart
+	switch *by {
+	case "band":
+		parts = cabrillo.SplitByBand(l)
+	case "transmitter":
+		parts = cabrillo.SplitByTransmitter(l)
+	case "time":
+		parts = cabrillo.SplitByTime(l, periods(l, *every)...)
+	}
+	sort.SliceStable(parts, func(i, j int) bool { return parts[i].Key < parts[j].Key })
+
+	for _, p := range parts {
+		key := strings.NewReplacer(" ", "-", "/", "_").Replace(p.Key)
+		name := filepath.Join(*dir, fmt.Sprintf("%s-%s.log", strings.ReplaceAll(l.CallSign, "/", "_"), key))
+		w, err := e.createFile(name)
+		if err != nil {
+			return err
+		}
+		if err := cabrillo.WriteLog(w, p.Log); err != nil {
+			w.Close()
+			return err
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+		fmt.Fprintln(e.stdout, name)
+	}
+	return nil
+}
+
+// periods returns consecutive periods of length d covering the QSOs of the
+// log, starting at 0000Z on the day of the first QSO.
+func periods(l cabrillo.Log, d time.Duration) []cabrillo.Period {
+	qsos := append(append([]cabrillo.QSO(nil), l.QSOs...), l.XQSOs...)
+	if len(qsos) == 0 {
+		return nil
+	}
+	cabrillo.SortQSOs(qsos)
+
+	first, last := qsos[0].Timestamp, qsos[len(qsos)-1].Timestamp
+	var ps []cabrillo.Period
+	for start := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, first.Location()); !start.After(last); start = start.Add(d) {
+		ps = append(ps, cabrillo.Period{Start: start, End: start.Add(d)})
+	}
+	return ps
+}
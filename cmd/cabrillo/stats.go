@@ -0,0 +1,159 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jasonhancock/go-cabrillo"
+)
+
+var statsCommand = command{
+	name:        "stats",
+	usage:       "[flags] [file...]",
+	description: "summarize the QSOs of logs",
+	run:         runStats,
+}
+
+// stats summarizes the QSOs of a log.
+type stats struct {
+	File     string `json:"file"`
+	Callsign string `json:"callsign"`
+	Contest  string `json:"contest"`
+	QSOs     int    `json:"qsos"`
+	XQSOs    int    `json:"x_qsos"`
+	Dupes    int    `json:"dupes"`
+	// Unique is the number of different callsigns worked.
+	Unique int `json:"unique_callsigns"`
+	// First and Last are the times of the first and the last QSO, empty when
+	// there are no QSOs.
+	First         string         `json:"first_qso"`
+	Last          string         `json:"last_qso"`
+	OperatingTime string         `json:"operating_time"`
+	Bands         map[string]int `json:"bands"`
+	Modes         map[string]int `json:"modes"`
+	Transmitters  map[int]int    `json:"transmitters"`
+}
+
+func runStats(e *env, fs *flag.FlagSet, args []string) error {
+	lf := addLogFlags(fs)
+	asJSON := fs.Bool("json", false, "write the statistics as JSON")
+	minOffTime := fs.Duration("min-off-time", time.Hour, "minimum gap between QSOs counted as off-time, unless the contest sets one")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	var all []stats
+	for _, name := range inputs(fs.Args()) {
+		l, err := e.readLog(name, lf)
+		if err != nil {
+			return err
+		}
+		s := logStats(l, *minOffTime)
+		s.File = displayName(name)
+		all = append(all, s)
+	}
+
+	if *asJSON {
+		return writeJSON(e.stdout, all)
+	}
+
+	for i, s := range all {
+		if i > 0 {
+			fmt.Fprintln(e.stdout)
+		}
+		printStats(e, s)
+	}
+	return nil
+}
+
+// logStats computes the statistics of the log.
+func logStats(l cabrillo.Log, minOffTime time.Duration) stats {
+	if c, err := cabrillo.LookupContest(l.Contest); err == nil && c.MinOffTime > 0 {
+		minOffTime = c.MinOffTime
+	}
+
+	s := stats{
+		Callsign:      l.CallSign,
+		Contest:       l.Contest,
+		QSOs:          len(l.QSOs),
+		XQSOs:         len(l.XQSOs),
+		Dupes:         len(cabrillo.FindDupes(l.QSOs, cabrillo.DupePerBand)),
+		OperatingTime: cabrillo.OperatingTime(l, minOffTime).String(),
+		Bands:         make(map[string]int),
+		Modes:         make(map[string]int),
+		Transmitters:  make(map[int]int),
+	}
+
+	const format = "2006-01-02 1504"
+	calls := make(map[string]struct{})
+	var first, last time.Time
+	for i, q := range l.QSOs {
+		calls[strings.ToUpper(q.RxInfo.Callsign)] = struct{}{}
+		s.Bands[bandName(q)]++
+		s.Modes[strings.ToUpper(q.Mode)]++
+		s.Transmitters[q.Transmitter]++
+
+		if i == 0 || q.Timestamp.Before(first) {
+			first = q.Timestamp
+		}
+		if i == 0 || q.Timestamp.After(last) {
+			last = q.Timestamp
+		}
+	}
+	s.Unique = len(calls)
+	if len(l.QSOs) > 0 {
+		s.First = first.Format(format)
+		s.Last = last.Format(format)
+	}
+
+	return s
+}
+
+func printStats(e *env, s stats) {
+	fmt.Fprintf(e.stdout, "%s: %s %s\n", s.File, s.Callsign, s.Contest)
+	fmt.Fprintf(e.stdout, "  QSOs:             %d (%d dupes, %d X-QSOs)\n", s.QSOs, s.Dupes, s.XQSOs)
+	fmt.Fprintf(e.stdout, "  Unique callsigns: %d\n", s.Unique)
+	if s.QSOs > 0 {
+		fmt.Fprintf(e.stdout, "  First QSO:        %s\n", s.First)
+		fmt.Fprintf(e.stdout, "  Last QSO:         %s\n", s.Last)
+		fmt.Fprintf(e.stdout, "  Operating time:   %s\n", s.OperatingTime)
+	}
+	printCounts(e, "Bands", s.Bands)
+	printCounts(e, "Modes", s.Modes)
+	if len(s.Transmitters) > 1 {
+		counts := make(map[string]int, len(s.Transmitters))
+		for tx, n := range s.Transmitters {
+			counts[fmt.Sprint(tx)] = n
+		}
+		printCounts(e, "Transmitters", counts)
+	}
+}
+
+// printCounts prints the counts sorted by key.
+func printCounts(e *env, title string, counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(e.stdout, "  %s:\n", title)
+	for _, k := range keys {
+		fmt.Fprintf(e.stdout, "    %-8s %6d\n", k, counts[k])
+	}
+}
+
+// bandName returns the band of the QSO, or its frequency if the band is
+// unknown.
+func bandName(q cabrillo.QSO) string {
+	if band := q.Band(); band != "" {
+		return band
+	}
+	return q.Frequency
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/jasonhancock/go-cabrillo"
+)
+
+// ruleCategory is the rule raising issues for categories with values the
+// specification doesn't allow.
+const ruleCategory = "CATEGORY"
+
+var validateCommand = command{
+	name:        "validate",
+	usage:       "[flags] [file...]",
+	description: "check logs against the specification and the contest rules",
+	run:         runValidate,
+}
+
+// fileIssues are the issues found in a file.
+type fileIssues struct {
+	File   string      `json:"file"`
+	Issues []jsonIssue `json:"issues"`
+}
+
+type jsonIssue struct {
+	// QSO is the 1-based number of the QSO, or 0 for the log as a whole.
+	QSO      int               `json:"qso"`
+	Severity cabrillo.Severity `json:"severity"`
+	Rule     string            `json:"rule"`
+	Message  string            `json:"message"`
+}
+
+func runValidate(e *env, fs *flag.FlagSet, args []string) error {
+	lf := addLogFlags(fs)
+	asJSON := fs.Bool("json", false, "write the issues as JSON")
+	scpFile := fs.String("scp", "", "warn about callsigns missing from this MASTER.SCP file")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	var scp *cabrillo.SCPDatabase
+	if *scpFile != "" {
+		r, err := e.open(*scpFile)
+		if err != nil {
+			return err
+		}
+		scp, err = cabrillo.ParseSCP(r)
+		r.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", *scpFile, err)
+		}
+	}
+
+	var results []fileIssues
+	var failed bool
+	for _, name := range inputs(fs.Args()) {
+		l, err := e.readLog(name, lf)
+		if err != nil {
+			return err
+		}
+
+		checks := logChecks(l)
+		if scp != nil {
+			checks = append(checks, scp.Check())
+		}
+
+		result := fileIssues{File: displayName(name), Issues: []jsonIssue{}}
+		for _, issue := range cabrillo.Validate(l, checks...) {
+			failed = failed || issue.Severity == cabrillo.SeverityError
+			result.Issues = append(result.Issues, jsonIssue{
+				QSO:      issue.QSO + 1,
+				Severity: issue.Severity,
+				Rule:     issue.Rule,
+				Message:  issue.Message,
+			})
+			if !*asJSON {
+				fmt.Fprintf(e.stdout, "%s: %s\n", displayName(name), issue)
+			}
+		}
+		results = append(results, result)
+	}
+
+	if *asJSON {
+		if err := writeJSON(e.stdout, results); err != nil {
+			return err
+		}
+	}
+	if failed {
+		return errIssues
+	}
+	return nil
+}
+
+// logChecks returns the checks that apply to the log. The timing rules of the
+// contest are checked when the contest is known.
+func logChecks(l cabrillo.Log) []cabrillo.Check {
+	checks := []cabrillo.Check{
+		categoryCheck,
+		cabrillo.CheckOffTimes,
+		cabrillo.ChronologyCheck(),
+		cabrillo.MultiTransmitterCheck,
+	}
+
+	c, err := cabrillo.LookupContest(l.Contest)
+	if err != nil {
+		return checks
+	}
+	if len(l.QSOs) > 0 {
+		checks = append(checks, cabrillo.PeriodCheck(c.Periods(l.QSOs[0].Timestamp.Year())...))
+	}
+	return append(checks, cabrillo.OperatingTimeCheck(c.MinOffTime))
+}
+
+// categoryCheck checks the categories against the values allowed by the
+// specification.
+func categoryCheck(l cabrillo.Log) []cabrillo.Issue {
+	var issues []cabrillo.Issue
+	rules := cabrillo.DefaultRules()
+	for _, c := range l.Categories {
+		for _, r := range rules {
+			if err := r.Evaluate(c); err != nil {
+				issues = append(issues, cabrillo.Issue{
+					QSO:      -1,
+					Severity: cabrillo.SeverityError,
+					Rule:     ruleCategory,
+					Message:  fmt.Sprintf("CATEGORY-%s: %v", strings.ToUpper(c.Name), err),
+				})
+			}
+		}
+	}
+	return issues
+}
@@ -0,0 +1,196 @@
+package cabrillo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RuleOutOfPeriod is the rule raising issues for QSOs made outside of the
+// contest period.
+const RuleOutOfPeriod = "OUT-OF-PERIOD"
+
+// LastWeekend is the Weekend of a contest held on the last full weekend of the
+// month.
+const LastWeekend = -1
+
+// Session is a part of a contest during which QSOs may be made. Contests with
+// mandated breaks have several sessions.
+type Session struct {
+	// Start is the time the session starts relative to 0000Z on the Saturday of
+	// the contest weekend.
+	Start    time.Duration
+	Duration time.Duration
+}
+
+// Contest defines when a contest is held and its timing rules.
+type Contest struct {
+	// Name is the value of the CONTEST field of logs for the contest.
+	Name  string
+	Month time.Month
+	// Weekend is the full weekend of the month the contest is held on, starting
+	// at 1, or LastWeekend. A full weekend is one where both the Saturday and
+	// the Sunday fall in the month.
+	Weekend  int
+	Sessions []Session
+	// MinOffTime is the minimum length of an off-time for time limited
+	// categories. It is zero for contests without time limited categories.
+	MinOffTime time.Duration
+}
+
+// contests are the contests known to LookupContest.
+var contests = []Contest{
+	{
+		Name:     "ARRL-DX-CW",
+		Month:    time.February,
+		Weekend:  3,
+		Sessions: []Session{{Start: 0, Duration: 48 * time.Hour}},
+	},
+	{
+		Name:     "ARRL-DX-SSB",
+		Month:    time.March,
+		Weekend:  1,
+		Sessions: []Session{{Start: 0, Duration: 48 * time.Hour}},
+	},
+	{
+		Name:       "ARRL-SS-CW",
+		Month:      time.November,
+		Weekend:    1,
+		Sessions:   []Session{{Start: 21 * time.Hour, Duration: 30 * time.Hour}},
+		MinOffTime: 30 * time.Minute,
+	},
+	{
+		Name:       "ARRL-SS-SSB",
+		Month:      time.November,
+		Weekend:    3,
+		Sessions:   []Session{{Start: 21 * time.Hour, Duration: 30 * time.Hour}},
+		MinOffTime: 30 * time.Minute,
+	},
+	{
+		Name:       "CQ-WPX-CW",
+		Month:      time.May,
+		Weekend:    LastWeekend,
+		Sessions:   []Session{{Start: 0, Duration: 48 * time.Hour}},
+		MinOffTime: 60 * time.Minute,
+	},
+	{
+		Name:       "CQ-WPX-RTTY",
+		Month:      time.February,
+		Weekend:    2,
+		Sessions:   []Session{{Start: 0, Duration: 48 * time.Hour}},
+		MinOffTime: 60 * time.Minute,
+	},
+	{
+		Name:       "CQ-WPX-SSB",
+		Month:      time.March,
+		Weekend:    LastWeekend,
+		Sessions:   []Session{{Start: 0, Duration: 48 * time.Hour}},
+		MinOffTime: 60 * time.Minute,
+	},
+	{
+		Name:       "CQ-WW-CW",
+		Month:      time.November,
+		Weekend:    LastWeekend,
+		Sessions:   []Session{{Start: 0, Duration: 48 * time.Hour}},
+		MinOffTime: 60 * time.Minute,
+	},
+	{
+		Name:       "CQ-WW-SSB",
+		Month:      time.October,
+		Weekend:    LastWeekend,
+		Sessions:   []Session{{Start: 0, Duration: 48 * time.Hour}},
+		MinOffTime: 60 * time.Minute,
+	},
+}
+
+// LookupContest returns the definition of the contest named in the CONTEST
+// field of a log.
+func LookupContest(name string) (Contest, error) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	for _, c := range contests {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+	return Contest{}, fmt.Errorf("unknown contest %q", name)
+}
+
+// Saturday returns the Saturday of the contest weekend in the year. It returns
+// the zero time if the month doesn't have enough full weekends.
+func (c Contest) Saturday(year int) time.Time {
+	var saturdays []time.Time
+	day := time.Date(year, c.Month, 1, 0, 0, 0, 0, time.UTC)
+	for day.Weekday() != time.Saturday {
+		day = day.AddDate(0, 0, 1)
+	}
+	// The Sunday must fall in the month as well for the weekend to be full.
+	for ; day.AddDate(0, 0, 1).Month() == c.Month; day = day.AddDate(0, 0, 7) {
+		saturdays = append(saturdays, day)
+	}
+
+	switch {
+	case c.Weekend == LastWeekend:
+		return saturdays[len(saturdays)-1]
+	case c.Weekend >= 1 && c.Weekend <= len(saturdays):
+		return saturdays[c.Weekend-1]
+	}
+	return time.Time{}
+}
+
+// Periods returns the periods of the contest in the year, one per session.
+func (c Contest) Periods(year int) []Period {
+	saturday := c.Saturday(year)
+	if saturday.IsZero() {
+		return nil
+	}
+
+	periods := make([]Period, 0, len(c.Sessions))
+	for _, s := range c.Sessions {
+		start := saturday.Add(s.Start)
+		periods = append(periods, Period{Start: start, End: start.Add(s.Duration)})
+	}
+	return periods
+}
+
+// PeriodCheck returns a Check that reports QSOs made before the first period
+// starts, after the last period ends or during a break between periods. The
+// periods must be in chronological order.
+func PeriodCheck(periods ...Period) Check {
+	return func(l Log) []Issue {
+		if len(periods) == 0 {
+			return nil
+		}
+
+		const format = "2006-01-02 1504"
+		start, end := periods[0].Start, periods[len(periods)-1].End
+
+		var issues []Issue
+		for i, q := range l.QSOs {
+			var msg string
+			switch {
+			case inPeriods(periods, q.Timestamp):
+				continue
+			case q.Timestamp.Before(start):
+				msg = fmt.Sprintf("QSO made before the contest started at %s", start.Format(format))
+			case !q.Timestamp.Before(end):
+				msg = fmt.Sprintf("QSO made after the contest ended at %s", end.Format(format))
+			default:
+				msg = "QSO made during a break"
+				for j := 1; j < len(periods); j++ {
+					if !q.Timestamp.Before(periods[j-1].End) && q.Timestamp.Before(periods[j].Start) {
+						msg = fmt.Sprintf("QSO made during the break from %s to %s", periods[j-1].End.Format(format), periods[j].Start.Format(format))
+						break
+					}
+				}
+			}
+
+			issues = append(issues, Issue{
+				QSO:      i,
+				Severity: SeverityError,
+				Rule:     RuleOutOfPeriod,
+				Message:  msg,
+			})
+		}
+		return issues
+	}
+}
@@ -0,0 +1,84 @@
+package cabrillo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContestPeriods(t *testing.T) {
+	tests := []struct {
+		contest string
+		year    int
+		start   string
+		end     string
+	}{
+		{"CQ-WW-CW", 2017, "2017-11-25T00:00:00Z", "2017-11-27T00:00:00Z"},
+		{"CQ-WW-SSB", 2023, "2023-10-28T00:00:00Z", "2023-10-30T00:00:00Z"},
+		// November 30th is a Saturday, but Sunday falls in December.
+		{"CQ-WW-CW", 2024, "2024-11-23T00:00:00Z", "2024-11-25T00:00:00Z"},
+		{"CQ-WPX-CW", 2023, "2023-05-27T00:00:00Z", "2023-05-29T00:00:00Z"},
+		{"CQ-WPX-RTTY", 2024, "2024-02-10T00:00:00Z", "2024-02-12T00:00:00Z"},
+		{"ARRL-DX-CW", 2024, "2024-02-17T00:00:00Z", "2024-02-19T00:00:00Z"},
+		// November 1st is a Saturday with Sunday in the month as well.
+		{"ARRL-SS-CW", 2025, "2025-11-01T21:00:00Z", "2025-11-03T03:00:00Z"},
+		{"cq-wpx-ssb", 2025, "2025-03-29T00:00:00Z", "2025-03-31T00:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.contest, func(t *testing.T) {
+			c, err := LookupContest(tt.contest)
+			require.NoError(t, err)
+
+			start, err := time.Parse(time.RFC3339, tt.start)
+			require.NoError(t, err)
+			end, err := time.Parse(time.RFC3339, tt.end)
+			require.NoError(t, err)
+
+			require.Equal(t, []Period{{Start: start, End: end}}, c.Periods(tt.year))
+		})
+	}
+
+	t.Run("unknown contest", func(t *testing.T) {
+		_, err := LookupContest("NOPE")
+		require.EqualError(t, err, `unknown contest "NOPE"`)
+	})
+
+	t.Run("not enough weekends", func(t *testing.T) {
+		c := Contest{Month: time.February, Weekend: 5, Sessions: []Session{{Duration: time.Hour}}}
+		require.True(t, c.Saturday(2023).IsZero())
+		require.Nil(t, c.Periods(2023))
+	})
+}
+
+func TestPeriodCheck(t *testing.T) {
+	c := Contest{
+		Name:    "TEST",
+		Month:   time.November,
+		Weekend: LastWeekend,
+		Sessions: []Session{
+			{Start: 0, Duration: 12 * time.Hour},
+			{Start: 24 * time.Hour, Duration: 12 * time.Hour},
+		},
+	}
+
+	l := Log{
+		QSOs: mustQSOs(t,
+			"QSO: 7030 CW 2017-11-24 2359 K1IR 599 5 SQ9E 599 15",
+			"QSO: 7030 CW 2017-11-25 0000 K1IR 599 5 EA2TT 599 14",
+			"QSO: 7030 CW 2017-11-25 1200 K1IR 599 5 HA3LN 599 15",
+			"QSO: 7030 CW 2017-11-26 1159 K1IR 599 5 HA9A 599 15",
+			"QSO: 7030 CW 2017-11-26 1200 K1IR 599 5 SP6CJK 599 15",
+		),
+	}
+
+	require.Equal(t, []Issue{
+		{QSO: 0, Severity: SeverityError, Rule: RuleOutOfPeriod, Message: "QSO made before the contest started at 2017-11-25 0000"},
+		{QSO: 2, Severity: SeverityError, Rule: RuleOutOfPeriod, Message: "QSO made during the break from 2017-11-25 1200 to 2017-11-26 0000"},
+		{QSO: 4, Severity: SeverityError, Rule: RuleOutOfPeriod, Message: "QSO made after the contest ended at 2017-11-26 1200"},
+	}, Validate(l, PeriodCheck(c.Periods(2017)...)))
+
+	require.Empty(t, Validate(l, PeriodCheck()))
+}
@@ -0,0 +1,124 @@
+package cabrillo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RuleUniqueCall is the rule raising issues for QSOs with callsigns that don't
+// appear in any other log of a corpus.
+const RuleUniqueCall = "UNIQUE-CALL"
+
+// Corpus is a set of logs from the same contest used to find callsigns that
+// were likely busted. A callsign worked by a single entrant is called a unique
+// and is often a busted copy of a station that was worked by others.
+type Corpus struct {
+	// counts is the number of logs each callsign was worked in.
+	counts map[string]int
+	// submitted are the callsigns of the stations that submitted a log.
+	submitted map[string]struct{}
+}
+
+// NewCorpus builds a corpus from the logs.
+func NewCorpus(logs []Log) *Corpus {
+	c := &Corpus{
+		counts:    make(map[string]int),
+		submitted: make(map[string]struct{}),
+	}
+
+	for _, l := range logs {
+		c.submitted[normalizeCall(stationCallsign(l))] = struct{}{}
+
+		worked := make(map[string]struct{})
+		for _, q := range l.QSOs {
+			worked[normalizeCall(q.RxInfo.Callsign)] = struct{}{}
+		}
+		for call := range worked {
+			c.counts[call]++
+		}
+	}
+
+	return c
+}
+
+// Count returns the number of logs the callsign was worked in.
+func (c *Corpus) Count(call string) int {
+	return c.counts[normalizeCall(call)]
+}
+
+// IsUnique returns true if the callsign was worked in a single log and the
+// station didn't submit a log.
+func (c *Corpus) IsUnique(call string) bool {
+	call = normalizeCall(call)
+	if _, ok := c.submitted[call]; ok {
+		return false
+	}
+	return c.counts[call] == 1
+}
+
+// Uniques returns the callsigns worked in a single log in alphabetical order.
+func (c *Corpus) Uniques() []string {
+	var uniques []string
+	for call := range c.counts {
+		if c.IsUnique(call) {
+			uniques = append(uniques, call)
+		}
+	}
+	sort.Strings(uniques)
+	return uniques
+}
+
+// NearMatches returns the callsigns of the corpus that differ from call by a
+// single character and aren't uniques themselves. They are the most likely
+// stations actually worked when call is busted. Callsigns worked in more logs
+// come first.
+func (c *Corpus) NearMatches(call string) []string {
+	var matches []string
+	for candidate := range c.counts {
+		if !c.IsUnique(candidate) && similarCalls(candidate, call) {
+			matches = append(matches, candidate)
+		}
+	}
+	for candidate := range c.submitted {
+		if _, ok := c.counts[candidate]; !ok && similarCalls(candidate, call) {
+			matches = append(matches, candidate)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if c.counts[matches[i]] != c.counts[matches[j]] {
+			return c.counts[matches[i]] > c.counts[matches[j]]
+		}
+		return matches[i] < matches[j]
+	})
+
+	return matches
+}
+
+// UniqueCheck returns a Check that warns about QSOs with uniques, suggesting
+// near matches from the corpus. The log checked is expected to be part of the
+// corpus.
+func (c *Corpus) UniqueCheck() Check {
+	return func(l Log) []Issue {
+		var issues []Issue
+		for i, q := range l.QSOs {
+			if !c.IsUnique(q.RxInfo.Callsign) {
+				continue
+			}
+
+			msg := fmt.Sprintf("%s was not worked by any other station", q.RxInfo.Callsign)
+			if matches := c.NearMatches(q.RxInfo.Callsign); len(matches) > 0 {
+				msg += fmt.Sprintf(", did you mean %s?", strings.Join(matches, " or "))
+			}
+
+			issues = append(issues, Issue{
+				QSO:      i,
+				Severity: SeverityWarning,
+				Rule:     RuleUniqueCall,
+				Message:  msg,
+			})
+		}
+		return issues
+	}
+}
@@ -0,0 +1,65 @@
+package cabrillo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorpus(t *testing.T) {
+	k1ir := Log{
+		CallSign: "K1IR",
+		QSOs: mustQSOs(t,
+			"QSO: 7030 CW 2017-11-25 2121 K1IR 599 5 SQ9E 599 15",
+			"QSO: 7030 CW 2017-11-25 2122 K1IR 599 5 EA2TT 599 14",
+			"QSO: 7030 CW 2017-11-25 2123 K1IR 599 5 HA9A 599 15",
+			"QSO: 7030 CW 2017-11-25 2124 K1IR 599 5 DL1ABD 599 14",
+			"QSO: 14030 CW 2017-11-25 2200 K1IR 599 5 SQ9E 599 15",
+		),
+	}
+	w1aw := Log{
+		CallSign: "W1AW",
+		QSOs: mustQSOs(t,
+			"QSO: 7030 CW 2017-11-25 2121 W1AW 599 5 SQ9E 599 15",
+			"QSO: 7030 CW 2017-11-25 2125 W1AW 599 5 DL1ABC 599 14",
+			"QSO: 7030 CW 2017-11-25 2126 W1AW 599 5 HA8A 599 15",
+		),
+	}
+	ea2tt := Log{
+		CallSign: "EA2TT",
+		QSOs: mustQSOs(t,
+			"QSO: 7030 CW 2017-11-25 2122 EA2TT 599 14 K1IR 599 5",
+			"QSO: 7030 CW 2017-11-25 2127 EA2TT 599 14 DL1ABC 599 14",
+		),
+	}
+
+	c := NewCorpus([]Log{k1ir, w1aw, ea2tt})
+
+	t.Run("counts", func(t *testing.T) {
+		require.Equal(t, 2, c.Count("SQ9E"))
+		require.Equal(t, 2, c.Count("dl1abc"))
+		require.Equal(t, 1, c.Count("HA9A"))
+		require.Equal(t, 0, c.Count("W1AW"))
+	})
+
+	t.Run("uniques", func(t *testing.T) {
+		require.Equal(t, []string{"DL1ABD", "HA8A", "HA9A"}, c.Uniques())
+		// EA2TT was only worked by K1IR, but submitted a log.
+		require.False(t, c.IsUnique("EA2TT"))
+	})
+
+	t.Run("near matches", func(t *testing.T) {
+		require.Equal(t, []string{"DL1ABC"}, c.NearMatches("DL1ABD"))
+		require.Empty(t, c.NearMatches("HA9A"))
+		require.Equal(t, []string{"W1AW"}, c.NearMatches("W1AX"))
+	})
+
+	t.Run("check", func(t *testing.T) {
+		issues := Validate(k1ir, c.UniqueCheck())
+		require.Equal(t, []Issue{
+			{QSO: 2, Severity: SeverityWarning, Rule: RuleUniqueCall, Message: "HA9A was not worked by any other station"},
+			{QSO: 3, Severity: SeverityWarning, Rule: RuleUniqueCall, Message: "DL1ABD was not worked by any other station, did you mean DL1ABC?"},
+		}, issues)
+		require.Equal(t, "WARNING UNIQUE-CALL: QSO 3: HA9A was not worked by any other station", issues[0].String())
+	})
+}
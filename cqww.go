@@ -0,0 +1,118 @@
+package cabrillo
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The kinds of multipliers counted in the CQ World Wide DX contest.
+const (
+	MultiplierZone    = "ZONE"
+	MultiplierCountry = "COUNTRY"
+)
+
+type cqwwScorer struct {
+	opts *scoreOptions
+}
+
+// NewCQWWScorer returns a Scorer implementing the rules of the CQ World Wide DX
+// contest (CQ-WW-CW and CQ-WW-SSB). The exchange is the CQ zone.
+//
+// QSOs with stations on a different continent count 3 points, QSOs with
+// stations on the same continent but in a different country count 1 point (2
+// points between stations in North America) and QSOs with stations in the same
+// country count 0 points. Each zone and each country counts as a multiplier
+// once per band. Stations may be worked once per band.
+func NewCQWWScorer(opts ...ScorerOption) Scorer {
+	return &cqwwScorer{opts: newScoreOptions(opts)}
+}
+
+func (s *cqwwScorer) Score(l Log) (Score, error) {
+	own, err := s.opts.entities.Lookup(stationCallsign(l))
+	if err != nil {
+		return Score{}, fmt.Errorf("resolving entity of the entrant: %w", err)
+	}
+
+	dupes := newDupeChecker(DupePerBand)
+	mults := make(multiplierTracker)
+	return s.opts.scoreQSOs(l, func(q QSO) QSOScore {
+		return s.scoreQSO(own, q, dupes, mults)
+	}), nil
+}
+
+func (s *cqwwScorer) scoreQSO(own Entity, q QSO, dupes *dupeChecker, mults multiplierTracker) QSOScore {
+	qs := QSOScore{QSO: q}
+
+	zone, err := parseCQZone(q.RxInfo.Exchange)
+	if err != nil {
+		qs.Status = QSOInvalidExchange
+		qs.Reason = err.Error()
+		return qs
+	}
+
+	if dupes.check(q) {
+		qs.Status = QSODupe
+		qs.Reason = "dupe"
+		return qs
+	}
+
+	band := q.Band()
+	if m := (Multiplier{Kind: MultiplierZone, Band: band, Value: strconv.Itoa(zone)}); mults.claim(m) {
+		qs.Multipliers = append(qs.Multipliers, m)
+	}
+
+	entity, err := s.opts.entities.Lookup(q.RxInfo.Callsign)
+	if err != nil {
+		qs.Status = QSOUnknownEntity
+		qs.Reason = err.Error()
+		return qs
+	}
+
+	qs.Status = QSOValid
+	if m := (Multiplier{Kind: MultiplierCountry, Band: band, Value: entity.Prefix}); mults.claim(m) {
+		qs.Multipliers = append(qs.Multipliers, m)
+	}
+
+	switch {
+	case entity.Name == own.Name:
+		qs.Points = 0
+	case entity.Continent != own.Continent:
+		qs.Points = 3
+	case own.Continent == "NA":
+		qs.Points = 2
+	default:
+		qs.Points = 1
+	}
+
+	return qs
+}
+
+// parseCQZone parses a CQ zone from a received exchange.
+func parseCQZone(exchange string) (int, error) {
+	exchange = strings.TrimSpace(exchange)
+	if exchange == "" {
+		return 0, errors.New("missing CQ zone")
+	}
+
+	zone, err := strconv.Atoi(exchange)
+	if err != nil || zone < 1 || zone > 40 {
+		return 0, fmt.Errorf("invalid CQ zone %q", exchange)
+	}
+
+	return zone, nil
+}
+
+// stationCallsign returns the callsign of the station that submitted the log.
+func stationCallsign(l Log) string {
+	if l.CallSign != "" {
+		return l.CallSign
+	}
+	for _, q := range l.QSOs {
+		if q.TxInfo.Callsign != "" {
+			return q.TxInfo.Callsign
+		}
+	}
+	return ""
+}
@@ -0,0 +1,124 @@
+package cabrillo
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCQWWScorer(t *testing.T) {
+	t.Run("cq-ww-dx.log", func(t *testing.T) {
+		fh, err := os.Open("testdata/cq-ww-dx.log")
+		require.NoError(t, err)
+		defer fh.Close()
+
+		l, err := ParseLog(fh)
+		require.NoError(t, err)
+
+		s, err := ScorerFor(l.Contest)
+		require.NoError(t, err)
+
+		score, err := s.Score(l)
+		require.NoError(t, err)
+
+		require.Len(t, score.QSOs, 5)
+		require.Equal(t, 5, score.Count(QSOValid))
+		// 0 (K9QZO) + 3 (P29AS) + 3 (4S7TWG) + 3 (JT1FAX) + 0 (WA6MIC)
+		require.Equal(t, 9, score.Points)
+		// A zone and a country on each of the 5 bands.
+		require.Equal(t, 10, score.Multipliers)
+		require.Equal(t, 90, score.Total)
+
+		require.Equal(t, []Multiplier{
+			{Kind: MultiplierZone, Band: Band20M, Value: "28"},
+			{Kind: MultiplierCountry, Band: Band20M, Value: "P2"},
+		}, score.QSOs[1].Multipliers)
+	})
+
+	t.Run("X-QSOs are excluded", func(t *testing.T) {
+		fh, err := os.Open("testdata/allfields.log")
+		require.NoError(t, err)
+		defer fh.Close()
+
+		l, err := ParseLog(fh)
+		require.NoError(t, err)
+
+		score, err := NewCQWWScorer().Score(l)
+		require.NoError(t, err)
+
+		require.Len(t, score.QSOs, 5)
+		require.Equal(t, 1, score.Count(QSOExcluded))
+		require.Equal(t, 9, score.Points)
+		require.Equal(t, 8, score.Multipliers)
+	})
+
+	t.Run("points", func(t *testing.T) {
+		tests := []struct {
+			description string
+			own         string
+			worked      string
+			expected    int
+		}{
+			{"same country", "K1IR", "W1AW", 0},
+			{"different continent", "K1IR", "SQ9E", 3},
+			{"same continent", "SQ9E", "HA9A", 1},
+			{"north america", "K1IR", "VE3AQ", 2},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.description, func(t *testing.T) {
+				l := Log{
+					CallSign: tt.own,
+					QSOs: []QSO{
+						{Frequency: "7030", Mode: "CW", RxInfo: Info{Callsign: tt.worked, Exchange: "15"}},
+					},
+				}
+
+				score, err := NewCQWWScorer().Score(l)
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, score.Points)
+			})
+		}
+	})
+
+	t.Run("dupes and invalid exchanges", func(t *testing.T) {
+		l := Log{
+			CallSign: "K1IR",
+			QSOs: []QSO{
+				{Frequency: "7030", Mode: "CW", RxInfo: Info{Callsign: "SQ9E", Exchange: "15"}},
+				{Frequency: "7031", Mode: "CW", RxInfo: Info{Callsign: "SQ9E", Exchange: "15"}},
+				{Frequency: "14030", Mode: "CW", RxInfo: Info{Callsign: "SQ9E", Exchange: "15"}},
+				{Frequency: "14030", Mode: "CW", RxInfo: Info{Callsign: "HA9A", Exchange: "41"}},
+				{Frequency: "14030", Mode: "CW", RxInfo: Info{Callsign: "W1AW/MM", Exchange: "8"}},
+			},
+		}
+
+		score, err := NewCQWWScorer().Score(l)
+		require.NoError(t, err)
+
+		require.Equal(t, QSOValid, score.QSOs[0].Status)
+		require.Equal(t, QSODupe, score.QSOs[1].Status)
+		require.Equal(t, QSOValid, score.QSOs[2].Status)
+		require.Equal(t, QSOInvalidExchange, score.QSOs[3].Status)
+		require.Equal(t, QSOUnknownEntity, score.QSOs[4].Status)
+		require.Equal(t, 6, score.Points)
+		// Zone 15 and SP on both bands plus zone 8 on 20M.
+		require.Equal(t, 5, score.Multipliers)
+		require.Equal(t, []int{1}, FindDupes(l.QSOs, DupePerBand))
+	})
+
+	t.Run("k1ir.log", func(t *testing.T) {
+		fh, err := os.Open("testdata/k1ir.log")
+		require.NoError(t, err)
+		defer fh.Close()
+
+		l, err := ParseLog(fh)
+		require.NoError(t, err)
+
+		score, err := NewCQWWScorer().Score(l)
+		require.NoError(t, err)
+		require.Equal(t, len(l.QSOs), score.Count(QSOValid))
+		require.Equal(t, l.ClaimedScore, score.Total)
+	})
+}
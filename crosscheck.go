@@ -0,0 +1,412 @@
+package cabrillo
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CheckStatus is the outcome of cross-checking a QSO against the log of the
+// station worked.
+type CheckStatus string
+
+// The possible outcomes of cross-checking a QSO.
+const (
+	// CheckMatched is a QSO found in the log of the station worked.
+	CheckMatched CheckStatus = "MATCHED"
+	// CheckNotInLog is a QSO not found in the log of the station worked.
+	CheckNotInLog CheckStatus = "NOT-IN-LOG"
+	// CheckBustedCall is a QSO where the callsign of the station worked was
+	// copied incorrectly.
+	CheckBustedCall CheckStatus = "BUSTED-CALL"
+	// CheckBustedExchange is a QSO where the exchange of the station worked was
+	// copied incorrectly.
+	CheckBustedExchange CheckStatus = "BUSTED-EXCHANGE"
+	// CheckTimeMismatch is a QSO found in the log of the station worked, but
+	// with a time outside of the allowed time window.
+	CheckTimeMismatch CheckStatus = "TIME-MISMATCH"
+	// CheckUnverified is a QSO with a station that didn't submit a log.
+	CheckUnverified CheckStatus = "UNVERIFIED"
+)
+
+// QSOCheck is the outcome of cross-checking a single QSO.
+type QSOCheck struct {
+	QSO    QSO
+	Status CheckStatus
+	// Counterpart is the matching QSO from the log of the station worked. It is
+	// nil when no matching QSO was found.
+	Counterpart *QSO
+	// CorrectCall is the callsign of the station most likely worked when the
+	// call was busted.
+	CorrectCall string
+	// Reason explains the status of QSOs that weren't matched.
+	Reason string
+}
+
+type crossCheckOptions struct {
+	timeWindow         time.Duration
+	timeMismatchWindow time.Duration
+}
+
+// CrossCheckOption is used to customize cross-checking.
+type CrossCheckOption func(*crossCheckOptions)
+
+// WithTimeWindow sets how far apart the times of both sides of a QSO can be to
+// still match. Defaults to 5 minutes.
+func WithTimeWindow(d time.Duration) CrossCheckOption {
+	return func(o *crossCheckOptions) {
+		o.timeWindow = d
+	}
+}
+
+// WithTimeMismatchWindow sets how far apart the times of both sides of a QSO
+// can be to be reported as a time mismatch rather than not in log. Defaults to
+// 60 minutes.
+func WithTimeMismatchWindow(d time.Duration) CrossCheckOption {
+	return func(o *crossCheckOptions) {
+		o.timeMismatchWindow = d
+	}
+}
+
+// crossChecker matches QSOs against a set of logs indexed by callsign. Each
+// QSO of the logs is the counterpart of at most one QSO.
+type crossChecker struct {
+	opts   *crossCheckOptions
+	logs   []Log
+	byCall map[string][]int
+	calls  []string
+	used   map[qsoRef]bool
+}
+
+// qsoRef is the position of a QSO in the logs of a crossChecker.
+type qsoRef struct {
+	log int
+	qso int
+}
+
+func newCrossChecker(logs []Log, opts []CrossCheckOption) *crossChecker {
+	opt := &crossCheckOptions{
+		timeWindow:         5 * time.Minute,
+		timeMismatchWindow: 60 * time.Minute,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	c := &crossChecker{
+		opts:   opt,
+		logs:   logs,
+		byCall: make(map[string][]int),
+		used:   make(map[qsoRef]bool),
+	}
+	for i, l := range logs {
+		call := normalizeCall(stationCallsign(l))
+		if _, ok := c.byCall[call]; !ok {
+			c.calls = append(c.calls, call)
+		}
+		c.byCall[call] = append(c.byCall[call], i)
+	}
+	sort.Strings(c.calls)
+
+	return c
+}
+
+// CrossCheck checks the QSOs in log a with the station that submitted log b
+// against log b. The result has an entry for each such QSO, including QSOs
+// where the callsign of b was busted, in the order they appear in a.
+func CrossCheck(a, b Log, opts ...CrossCheckOption) []QSOCheck {
+	c := newCrossChecker([]Log{b}, opts)
+	bCall := normalizeCall(stationCallsign(b))
+
+	var checks []QSOCheck
+	for i, check := range c.check([]Log{a})[0] {
+		switch {
+		case check != nil:
+			checks = append(checks, *check)
+		case normalizeCall(a.QSOs[i].RxInfo.Callsign) == bCall:
+			checks = append(checks, notInLog(a.QSOs[i]))
+		}
+	}
+
+	return checks
+}
+
+// CrossCheckLogs checks every QSO of every log against the logs of the other
+// stations. The result has an entry for each log, in the same order as the
+// logs, with an entry for each of its QSOs in the order they appear in the log.
+// Several logs can have the same callsign, like the logs of the positions of
+// a multi-transmitter station, and are checked as a single log. QSOs with
+// stations that didn't submit a log are reported as CheckUnverified unless the
+// call appears to be a busted copy of a station that did.
+func CrossCheckLogs(logs []Log, opts ...CrossCheckOption) [][]QSOCheck {
+	c := newCrossChecker(logs, opts)
+
+	results := make([][]QSOCheck, len(logs))
+	for i, checks := range c.check(logs) {
+		results[i] = make([]QSOCheck, 0, len(checks))
+		for j, check := range checks {
+			q := logs[i].QSOs[j]
+			switch {
+			case check != nil:
+				results[i] = append(results[i], *check)
+			case len(c.byCall[normalizeCall(q.RxInfo.Callsign)]) > 0:
+				results[i] = append(results[i], notInLog(q))
+			default:
+				results[i] = append(results[i], QSOCheck{
+					QSO:    q,
+					Status: CheckUnverified,
+					Reason: fmt.Sprintf("no log from %s", q.RxInfo.Callsign),
+				})
+			}
+		}
+	}
+
+	return results
+}
+
+// check pairs the QSOs of the logs with their counterparts in the logs of the
+// checker. The result has an entry for each QSO of each log, which is nil when
+// no counterpart was found.
+//
+// QSOs are paired in order of confidence: QSOs logged by both stations within
+// the time window first, then QSOs where one of the calls was busted and
+// finally QSOs with a time mismatch. This keeps a QSO logged at the right time
+// from being taken as the counterpart of another QSO, like a dupe.
+func (c *crossChecker) check(logs []Log) [][]*QSOCheck {
+	checks := make([][]*QSOCheck, len(logs))
+	for i, l := range logs {
+		checks[i] = make([]*QSOCheck, len(l.QSOs))
+	}
+
+	steps := []func(own string, q QSO) (QSOCheck, bool){
+		c.checkMatch,
+		c.checkBustedOwnCall,
+		c.checkBustedCall,
+		c.checkTimeMismatch,
+	}
+	for _, step := range steps {
+		for i, l := range logs {
+			own := normalizeCall(stationCallsign(l))
+			for j, q := range l.QSOs {
+				if checks[i][j] != nil {
+					continue
+				}
+				if check, ok := step(own, q); ok {
+					checks[i][j] = &check
+				}
+			}
+		}
+	}
+
+	return checks
+}
+
+// checkMatch looks for the QSO in the logs of the station worked within the
+// time window.
+func (c *crossChecker) checkMatch(own string, q QSO) (QSOCheck, bool) {
+	counterpart, ok := c.claim(normalizeCall(q.RxInfo.Callsign), q, c.opts.timeWindow, func(cq QSO) bool {
+		return normalizeCall(cq.RxInfo.Callsign) == own
+	})
+	if !ok {
+		return QSOCheck{}, false
+	}
+	return matched(q, counterpart), true
+}
+
+// checkBustedOwnCall looks for the QSO in the logs of the station worked with
+// a busted copy of own within the time window. That's the other station's
+// error, not ours.
+func (c *crossChecker) checkBustedOwnCall(own string, q QSO) (QSOCheck, bool) {
+	counterpart, ok := c.claim(normalizeCall(q.RxInfo.Callsign), q, c.opts.timeWindow, func(cq QSO) bool {
+		return similarCalls(cq.RxInfo.Callsign, own)
+	})
+	if !ok {
+		return QSOCheck{}, false
+	}
+
+	check := matched(q, counterpart)
+	if check.Status == CheckMatched {
+		check.Reason = fmt.Sprintf("%s logged %s", q.RxInfo.Callsign, counterpart.RxInfo.Callsign)
+	}
+	return check, true
+}
+
+// checkBustedCall looks for a log of a station with a callsign similar to the
+// one in the QSO that logged a QSO with own at the same time on the same band
+// and mode.
+func (c *crossChecker) checkBustedCall(own string, q QSO) (QSOCheck, bool) {
+	worked := normalizeCall(q.RxInfo.Callsign)
+	for _, call := range c.calls {
+		if call == own || call == worked || !similarCalls(call, worked) {
+			continue
+		}
+
+		counterpart, ok := c.claim(call, q, c.opts.timeWindow, func(cq QSO) bool {
+			return normalizeCall(cq.RxInfo.Callsign) == own
+		})
+		if !ok {
+			continue
+		}
+
+		return QSOCheck{
+			QSO:         q,
+			Status:      CheckBustedCall,
+			Counterpart: counterpart,
+			CorrectCall: call,
+			Reason:      fmt.Sprintf("copied %s, station worked was %s", q.RxInfo.Callsign, call),
+		}, true
+	}
+
+	return QSOCheck{}, false
+}
+
+// checkTimeMismatch looks for the QSO in the logs of the station worked
+// within the time mismatch window.
+func (c *crossChecker) checkTimeMismatch(own string, q QSO) (QSOCheck, bool) {
+	counterpart, ok := c.claim(normalizeCall(q.RxInfo.Callsign), q, c.opts.timeMismatchWindow, func(cq QSO) bool {
+		return normalizeCall(cq.RxInfo.Callsign) == own
+	})
+	if !ok {
+		return QSOCheck{}, false
+	}
+
+	d := counterpart.Timestamp.Sub(q.Timestamp)
+	if d < 0 {
+		d = -d
+	}
+	return QSOCheck{
+		QSO:         q,
+		Status:      CheckTimeMismatch,
+		Counterpart: counterpart,
+		Reason:      fmt.Sprintf("logged by %s %s apart", q.RxInfo.Callsign, d),
+	}, true
+}
+
+// matched returns the check of a QSO paired with its counterpart, comparing
+// the exchange copied with the exchange sent.
+func matched(q QSO, counterpart *QSO) QSOCheck {
+	check := QSOCheck{QSO: q, Counterpart: counterpart}
+	if exchangesEqual(q.RxInfo.Exchange, counterpart.TxInfo.Exchange) {
+		check.Status = CheckMatched
+	} else {
+		check.Status = CheckBustedExchange
+		check.Reason = fmt.Sprintf("copied %q, %s sent %q", q.RxInfo.Exchange, q.RxInfo.Callsign, counterpart.TxInfo.Exchange)
+	}
+	return check
+}
+
+// notInLog returns the check of a QSO not found in the log of the station
+// worked.
+func notInLog(q QSO) QSOCheck {
+	return QSOCheck{
+		QSO:    q,
+		Status: CheckNotInLog,
+		Reason: fmt.Sprintf("not in the log of %s", q.RxInfo.Callsign),
+	}
+}
+
+// claim returns the QSO closest in time to q on the same band and mode in the
+// logs of the station for which match returns true, if it is within the
+// window. QSOs already claimed as the counterpart of another QSO are skipped,
+// and the QSO returned is marked as claimed.
+func (c *crossChecker) claim(call string, q QSO, window time.Duration, match func(QSO) bool) (*QSO, bool) {
+	var (
+		best  qsoRef
+		bestD time.Duration
+		found bool
+	)
+	band := q.Band()
+	for _, i := range c.byCall[call] {
+		for j, cq := range c.logs[i].QSOs {
+			ref := qsoRef{log: i, qso: j}
+			if c.used[ref] || cq.Band() != band || !strings.EqualFold(cq.Mode, q.Mode) || !match(cq) {
+				continue
+			}
+
+			d := cq.Timestamp.Sub(q.Timestamp)
+			if d < 0 {
+				d = -d
+			}
+			if !found || d < bestD {
+				best, bestD, found = ref, d, true
+			}
+		}
+	}
+	if !found || bestD > window {
+		return nil, false
+	}
+
+	c.used[best] = true
+	counterpart := c.logs[best.log].QSOs[best.qso]
+	return &counterpart, true
+}
+
+// exchangesEqual compares two exchanges field by field. Numeric fields are
+// compared by value, so "05" equals "5".
+func exchangesEqual(a, b string) bool {
+	af := strings.Fields(strings.ToUpper(a))
+	bf := strings.Fields(strings.ToUpper(b))
+	if len(af) != len(bf) {
+		return false
+	}
+
+	for i := range af {
+		if af[i] == bf[i] {
+			continue
+		}
+		an, aErr := strconv.Atoi(af[i])
+		bn, bErr := strconv.Atoi(bf[i])
+		if aErr != nil || bErr != nil || an != bn {
+			return false
+		}
+	}
+
+	return true
+}
+
+// normalizeCall returns the callsign in upper case without surrounding spaces.
+func normalizeCall(call string) string {
+	return strings.ToUpper(strings.TrimSpace(call))
+}
+
+// similarCalls returns true if the callsigns differ by a single character
+// being added, removed or replaced.
+func similarCalls(a, b string) bool {
+	a, b = normalizeCall(a), normalizeCall(b)
+	return a != b && editDistance(a, b) == 1
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
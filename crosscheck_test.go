@@ -0,0 +1,160 @@
+package cabrillo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustQSOs(t *testing.T, lines ...string) []QSO {
+	t.Helper()
+	var qsos []QSO
+	for _, line := range lines {
+		q, err := NewQSO(line, 1)
+		require.NoError(t, err)
+		qsos = append(qsos, q)
+	}
+	return qsos
+}
+
+func TestCrossCheck(t *testing.T) {
+	a := Log{
+		CallSign: "K1IR",
+		QSOs: mustQSOs(t,
+			"QSO: 7030 CW 2017-11-25 2121 K1IR 599 5 SQ9E 599 15",
+			"QSO: 7030 CW 2017-11-25 2122 K1IR 599 5 EA2TT 599 14",
+			"QSO: 7030 CW 2017-11-25 2123 K1IR 599 5 HA9A 599 15",
+			"QSO: 7030 CW 2017-11-25 2124 K1IR 599 5 HA3LN 599 15",
+			"QSO: 7030 CW 2017-11-25 2125 K1IR 599 5 SP6CJK 599 15",
+			"QSO: 14030 CW 2017-11-25 2200 K1IR 599 5 SQ9E 599 15",
+			"QSO: 7030 CW 2017-11-25 2126 K1IR 599 5 DL1ABC 599 14",
+			"QSO: 7030 CW 2017-11-25 2140 K1IR 599 5 HA8A 599 15",
+		),
+	}
+	sq9e := Log{
+		CallSign: "SQ9E",
+		QSOs: mustQSOs(t,
+			"QSO: 7030 CW 2017-11-25 2122 SQ9E 599 15 K1IR 599 5",
+		),
+	}
+	ea2tt := Log{
+		CallSign: "EA2TT",
+		QSOs: mustQSOs(t,
+			"QSO: 7030 CW 2017-11-25 2122 EA2TT 599 14 K1IR 599 5",
+		),
+	}
+	ha9a := Log{
+		CallSign: "HA9A",
+		QSOs: mustQSOs(t,
+			"QSO: 7030 CW 2017-11-25 2150 HA9A 599 15 K1IR 599 5",
+			"QSO: 7030 CW 2017-11-25 2140 HA9A 599 15 K1IR 599 5",
+		),
+	}
+	ha3ln := Log{
+		CallSign: "HA3LN",
+		QSOs: mustQSOs(t,
+			"QSO: 7030 CW 2017-11-25 2124 HA3LN 599 16 K1IR 599 5",
+		),
+	}
+	sp6cjk := Log{
+		CallSign: "SP6CJK",
+		QSOs: mustQSOs(t,
+			"QSO: 7030 CW 2017-11-25 2125 SP6CJK 599 15 K1IF 599 5",
+		),
+	}
+
+	t.Run("CrossCheck", func(t *testing.T) {
+		checks := CrossCheck(a, sq9e)
+		require.Len(t, checks, 2)
+		require.Equal(t, CheckMatched, checks[0].Status)
+		require.Equal(t, "K1IR", checks[0].Counterpart.RxInfo.Callsign)
+		require.Equal(t, CheckNotInLog, checks[1].Status)
+		require.Nil(t, checks[1].Counterpart)
+
+		checks = CrossCheck(a, ha9a)
+		require.Len(t, checks, 2)
+		require.Equal(t, CheckTimeMismatch, checks[0].Status)
+		require.Equal(t, CheckBustedCall, checks[1].Status)
+		require.Equal(t, "HA9A", checks[1].CorrectCall)
+
+		checks = CrossCheck(a, ha9a, WithTimeMismatchWindow(10*time.Minute))
+		require.Equal(t, CheckNotInLog, checks[0].Status)
+	})
+
+	t.Run("CrossCheckLogs", func(t *testing.T) {
+		results := CrossCheckLogs([]Log{a, sq9e, ea2tt, ha9a, ha3ln, sp6cjk})
+		require.Len(t, results, 6)
+
+		checks := results[0]
+		require.Len(t, checks, len(a.QSOs))
+
+		expected := []CheckStatus{
+			CheckMatched,
+			CheckMatched,
+			CheckTimeMismatch,
+			CheckBustedExchange,
+			CheckMatched,
+			CheckNotInLog,
+			CheckUnverified,
+			CheckBustedCall,
+		}
+		for i, check := range checks {
+			require.Equal(t, expected[i], check.Status, "QSO %d: %s", i, check.Reason)
+		}
+		require.Equal(t, `copied "15", HA3LN sent "16"`, checks[3].Reason)
+		require.Equal(t, "SP6CJK logged K1IF", checks[4].Reason)
+
+		checks = results[5]
+		require.Len(t, checks, 1)
+		require.Equal(t, CheckBustedCall, checks[0].Status)
+		require.Equal(t, "K1IR", checks[0].CorrectCall)
+	})
+
+	t.Run("dupe", func(t *testing.T) {
+		dupe := Log{
+			CallSign: "K1IR",
+			QSOs: mustQSOs(t,
+				"QSO: 7030 CW 2017-11-25 2121 K1IR 599 5 SQ9E 599 15",
+				"QSO: 7030 CW 2017-11-25 2130 K1IR 599 5 SQ9E 599 15",
+			),
+		}
+
+		checks := CrossCheck(dupe, sq9e)
+		require.Len(t, checks, 2)
+		require.Equal(t, CheckMatched, checks[0].Status)
+		require.Equal(t, CheckNotInLog, checks[1].Status)
+	})
+
+	t.Run("busted own call and exchange", func(t *testing.T) {
+		other := Log{
+			CallSign: "SP6CJK",
+			QSOs:     mustQSOs(t, "QSO: 7030 CW 2017-11-25 2125 SP6CJK 599 16 K1IF 599 5"),
+		}
+
+		checks := CrossCheck(a, other)
+		require.Len(t, checks, 1)
+		require.Equal(t, CheckBustedExchange, checks[0].Status)
+		require.Equal(t, `copied "15", SP6CJK sent "16"`, checks[0].Reason)
+	})
+
+	t.Run("logs with the same call", func(t *testing.T) {
+		run := Log{CallSign: "SQ9E", QSOs: mustQSOs(t, "QSO: 7030 CW 2017-11-25 2122 SQ9E 599 15 K1IR 599 5")}
+		mult := Log{CallSign: "SQ9E", QSOs: mustQSOs(t, "QSO: 14030 CW 2017-11-25 2200 SQ9E 599 15 K1IR 599 5")}
+
+		results := CrossCheckLogs([]Log{a, run, mult})
+		require.Len(t, results, 3)
+		require.Equal(t, CheckMatched, results[0][0].Status)
+		require.Equal(t, CheckMatched, results[0][5].Status)
+		require.Equal(t, CheckMatched, results[1][0].Status)
+		require.Equal(t, CheckMatched, results[2][0].Status)
+	})
+}
+
+func TestEditDistance(t *testing.T) {
+	require.Equal(t, 0, editDistance("K1IR", "K1IR"))
+	require.Equal(t, 1, editDistance("K1IR", "K1IF"))
+	require.Equal(t, 1, editDistance("K1IR", "K1IRR"))
+	require.Equal(t, 2, editDistance("K1IR", "1IF"))
+	require.Equal(t, 4, editDistance("", "K1IR"))
+}
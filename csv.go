@@ -0,0 +1,271 @@
+package cabrillo
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvTimeFormat is the format of the timestamp column, which spreadsheets
+// recognize as a date and time.
+const csvTimeFormat = "2006-01-02 15:04"
+
+type csvOptions struct {
+	exchangeColumns int
+}
+
+// CSVOption is used to customize the CSV output.
+type CSVOption func(*csvOptions)
+
+// WithExchangeColumns sets the number of exchange columns written for each
+// side of the QSOs. Defaults to 1. With 0 columns the exchanges are left out.
+func WithExchangeColumns(n int) CSVOption {
+	return func(o *csvOptions) {
+		o.exchangeColumns = n
+	}
+}
+
+// WriteCSV writes the QSOs and X-QSOs of the log as CSV with a header row. The
+// columns are frequency, band, mode, timestamp, tx_call, tx_rst, tx_exchange_1
+// to tx_exchange_N, rx_call, rx_rst, rx_exchange_1 to rx_exchange_N,
+// transmitter and x_qso. The number of exchange columns is set with
+// WithExchangeColumns. Exchanges with more fields than that have the extra
+// fields in the last column.
+func WriteCSV(w io.Writer, l Log, opts ...CSVOption) error {
+	opt := &csvOptions{
+		exchangeColumns: 1,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader(opt.exchangeColumns)); err != nil {
+		return err
+	}
+
+	write := func(qsos []QSO, xqso bool) error {
+		for _, q := range qsos {
+			row := []string{q.Frequency, q.Band(), q.Mode, q.Timestamp.Format(csvTimeFormat)}
+			for _, info := range []Info{q.TxInfo, q.RxInfo} {
+				var rst string
+				if info.SignalReport != (RST{}) {
+					rst = info.SignalReport.String()
+				}
+				row = append(row, info.Callsign, rst)
+				row = append(row, splitExchange(info.Exchange, opt.exchangeColumns)...)
+			}
+			row = append(row, strconv.Itoa(q.Transmitter), strconv.FormatBool(xqso))
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := write(l.QSOs, false); err != nil {
+		return err
+	}
+	if err := write(l.XQSOs, true); err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadCSV builds a log from CSV written by WriteCSV. The columns are matched
+// by the names in the header row, so they may be reordered and the band column
+// and other unknown columns are ignored. The header fields of the log are
+// copied from header.
+func ReadCSV(r io.Reader, header Log) (Log, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	names, err := cr.Read()
+	if err != nil {
+		return Log{}, fmt.Errorf("reading header row: %w", err)
+	}
+	cols, err := newCSVColumns(names)
+	if err != nil {
+		return Log{}, err
+	}
+
+	l := header
+	l.QSOs = nil
+	l.XQSOs = nil
+	for line := 2; ; line++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Log{}, err
+		}
+
+		q, xqso, err := cols.qso(row)
+		if err != nil {
+			return Log{}, fmt.Errorf("line %d: %w", line, err)
+		}
+		if xqso {
+			l.XQSOs = append(l.XQSOs, q)
+		} else {
+			l.QSOs = append(l.QSOs, q)
+		}
+	}
+
+	return l, nil
+}
+
+// csvColumns maps the columns of a CSV file to their index.
+type csvColumns struct {
+	named map[string]int
+	// exchanges has the indexes of the exchange columns of tx and rx in the
+	// order of the fields.
+	exchanges map[string][]int
+}
+
+func newCSVColumns(names []string) (*csvColumns, error) {
+	c := &csvColumns{
+		named:     make(map[string]int),
+		exchanges: make(map[string][]int),
+	}
+	fields := make(map[string]map[int]int)
+	for i, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		c.named[name] = i
+
+		for _, prefix := range []string{"tx", "rx"} {
+			n, err := strconv.Atoi(strings.TrimPrefix(name, prefix+"_exchange_"))
+			if !strings.HasPrefix(name, prefix+"_exchange_") || err != nil || n < 1 {
+				continue
+			}
+			if fields[prefix] == nil {
+				fields[prefix] = make(map[int]int)
+			}
+			fields[prefix][n] = i
+		}
+	}
+
+	for _, name := range []string{"frequency", "mode", "timestamp", "tx_call", "rx_call"} {
+		if _, ok := c.named[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	for prefix, byField := range fields {
+		for n := 1; n <= len(byField); n++ {
+			i, ok := byField[n]
+			if !ok {
+				return nil, fmt.Errorf("missing column %q", fmt.Sprintf("%s_exchange_%d", prefix, n))
+			}
+			c.exchanges[prefix] = append(c.exchanges[prefix], i)
+		}
+	}
+
+	return c, nil
+}
+
+// value returns the value of the column in the row or an empty string if the
+// column doesn't exist.
+func (c *csvColumns) value(row []string, i int) string {
+	if i < 0 || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+func (c *csvColumns) col(row []string, name string) string {
+	i, ok := c.named[name]
+	if !ok {
+		return ""
+	}
+	return c.value(row, i)
+}
+
+// qso builds a QSO from a row and returns whether it's an X-QSO.
+func (c *csvColumns) qso(row []string) (QSO, bool, error) {
+	q := QSO{
+		Frequency: c.col(row, "frequency"),
+		Mode:      c.col(row, "mode"),
+	}
+
+	var err error
+	q.Timestamp, err = time.Parse(csvTimeFormat, c.col(row, "timestamp"))
+	if err != nil {
+		return QSO{}, false, fmt.Errorf("parsing timestamp: %w", err)
+	}
+
+	for _, side := range []struct {
+		prefix string
+		info   *Info
+	}{
+		{"tx", &q.TxInfo},
+		{"rx", &q.RxInfo},
+	} {
+		side.info.Callsign = c.col(row, side.prefix+"_call")
+		if rst := c.col(row, side.prefix+"_rst"); rst != "" {
+			side.info.SignalReport, err = NewRST(rst)
+			if err != nil {
+				return QSO{}, false, fmt.Errorf("parsing %s_rst: %w", side.prefix, err)
+			}
+		}
+
+		var fields []string
+		for _, i := range c.exchanges[side.prefix] {
+			fields = append(fields, c.value(row, i))
+		}
+		side.info.Exchange = joinNonEmpty(fields...)
+	}
+
+	if tx := c.col(row, "transmitter"); tx != "" {
+		q.Transmitter, err = strconv.Atoi(tx)
+		if err != nil {
+			return QSO{}, false, fmt.Errorf("parsing transmitter: %w", err)
+		}
+	}
+
+	var xqso bool
+	if v := c.col(row, "x_qso"); v != "" {
+		xqso, err = strconv.ParseBool(v)
+		if err != nil {
+			return QSO{}, false, fmt.Errorf("parsing x_qso: %w", err)
+		}
+	}
+
+	return q, xqso, nil
+}
+
+// csvHeader returns the header row for the number of exchange columns.
+func csvHeader(exchangeColumns int) []string {
+	header := []string{"frequency", "band", "mode", "timestamp"}
+	for _, prefix := range []string{"tx", "rx"} {
+		header = append(header, prefix+"_call", prefix+"_rst")
+		for i := 1; i <= exchangeColumns; i++ {
+			header = append(header, fmt.Sprintf("%s_exchange_%d", prefix, i))
+		}
+	}
+	return append(header, "transmitter", "x_qso")
+}
+
+// splitExchange splits the exchange into n columns. Extra fields go into the
+// last column.
+func splitExchange(exchange string, n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	columns := make([]string, n)
+	fields := strings.Fields(exchange)
+	for i, f := range fields {
+		if i < n-1 {
+			columns[i] = f
+			continue
+		}
+		columns[n-1] = strings.Join(fields[n-1:], " ")
+		break
+	}
+	return columns
+}
@@ -0,0 +1,100 @@
+package cabrillo
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSV(t *testing.T) {
+	l := Log{
+		CallSign: "N8BJQ",
+		QSOs: mustQSOs(t,
+			"QSO: 14025 CW 2023-05-27 0001 N8BJQ 599 1 DL1ABC 599 12",
+			"QSO: 144 PH 2023-05-27 0002 N8BJQ 59 2 W1AW 59 FN31 1",
+		),
+		XQSOs: mustQSOs(t,
+			"QSO: 7025 CW 2023-05-27 0003 N8BJQ 599 3 K1IR 599 5",
+		),
+	}
+
+	t.Run("write", func(t *testing.T) {
+		var buf strings.Builder
+		require.NoError(t, WriteCSV(&buf, l))
+		require.Equal(t, `frequency,band,mode,timestamp,tx_call,tx_rst,tx_exchange_1,rx_call,rx_rst,rx_exchange_1,transmitter,x_qso
+14025,20M,CW,2023-05-27 00:01,N8BJQ,599,1,DL1ABC,599,12,0,false
+144,2M,PH,2023-05-27 00:02,N8BJQ,59,2,W1AW,59,FN31,1,false
+7025,40M,CW,2023-05-27 00:03,N8BJQ,599,3,K1IR,599,5,0,true
+`, buf.String())
+
+		converted, err := ReadCSV(strings.NewReader(buf.String()), Log{CallSign: "N8BJQ"})
+		require.NoError(t, err)
+		require.Equal(t, l, converted)
+	})
+
+	t.Run("exchange fields", func(t *testing.T) {
+		fh, err := os.Open("testdata/arrl-ss-cw.log")
+		require.NoError(t, err)
+		defer fh.Close()
+
+		ss, err := ParseLog(fh, WithExchangeFields(4), WithoutSignalReport())
+		require.NoError(t, err)
+
+		var buf strings.Builder
+		require.NoError(t, WriteCSV(&buf, ss, WithExchangeColumns(4)))
+		lines := strings.Split(buf.String(), "\n")
+		require.Equal(t, "frequency,band,mode,timestamp,tx_call,tx_rst,tx_exchange_1,tx_exchange_2,tx_exchange_3,tx_exchange_4,rx_call,rx_rst,rx_exchange_1,rx_exchange_2,rx_exchange_3,rx_exchange_4,transmitter,x_qso", lines[0])
+		require.Equal(t, 18, len(strings.Split(lines[1], ",")))
+
+		converted, err := ReadCSV(strings.NewReader(buf.String()), ss)
+		require.NoError(t, err)
+		require.Equal(t, ss, converted)
+
+		// Too few columns keep the remaining fields in the last one.
+		buf.Reset()
+		require.NoError(t, WriteCSV(&buf, ss, WithExchangeColumns(2)))
+		converted, err = ReadCSV(strings.NewReader(buf.String()), ss)
+		require.NoError(t, err)
+		require.Equal(t, ss.QSOs, converted.QSOs)
+	})
+
+	t.Run("no exchange columns", func(t *testing.T) {
+		var buf strings.Builder
+		require.NoError(t, WriteCSV(&buf, l, WithExchangeColumns(0)))
+		require.Equal(t, `frequency,band,mode,timestamp,tx_call,tx_rst,rx_call,rx_rst,transmitter,x_qso
+14025,20M,CW,2023-05-27 00:01,N8BJQ,599,DL1ABC,599,0,false
+144,2M,PH,2023-05-27 00:02,N8BJQ,59,W1AW,59,1,false
+7025,40M,CW,2023-05-27 00:03,N8BJQ,599,K1IR,599,0,true
+`, buf.String())
+	})
+
+	t.Run("reordered columns", func(t *testing.T) {
+		input := "rx_call,timestamp,mode,frequency,tx_call,rx_exchange_2,rx_exchange_1,notes\n" +
+			"W1AW,2023-11-04 21:00,CW,7030,N8BJQ,A,1,ignored\n"
+		converted, err := ReadCSV(strings.NewReader(input), Log{})
+		require.NoError(t, err)
+		require.Len(t, converted.QSOs, 1)
+		require.Equal(t, "1 A", converted.QSOs[0].RxInfo.Exchange)
+		require.Equal(t, RST{}, converted.QSOs[0].RxInfo.SignalReport)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			input string
+			err   string
+		}{
+			{"", "reading header row: EOF"},
+			{"frequency,mode,timestamp,tx_call\n", `missing column "rx_call"`},
+			{"frequency,mode,timestamp,tx_call,rx_call,rx_exchange_2\n", `missing column "rx_exchange_1"`},
+			{"frequency,mode,timestamp,tx_call,rx_call\n7030,CW,2023-11-04,N8BJQ,W1AW\n", `line 2: parsing timestamp: parsing time "2023-11-04"`},
+			{"frequency,mode,timestamp,tx_call,rx_call,x_qso\n7030,CW,2023-11-04 21:00,N8BJQ,W1AW,maybe\n", `line 2: parsing x_qso: strconv.ParseBool: parsing "maybe": invalid syntax`},
+		}
+		for _, tt := range tests {
+			_, err := ReadCSV(strings.NewReader(tt.input), Log{})
+			require.Error(t, err)
+			require.True(t, strings.HasPrefix(err.Error(), tt.err), err.Error())
+		}
+	})
+}
@@ -0,0 +1,622 @@
+package cabrillo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ediBands maps bands to their names in the PBand field of EDI files.
+var ediBands = []struct {
+	band string
+	edi  string
+}{
+	{Band6M, "50 MHz"},
+	{Band4M, "70 MHz"},
+	{Band2M, "144 MHz"},
+	{Band432, "432 MHz"},
+	{Band1200, "1,3 GHz"},
+	{Band2300, "2,3 GHz"},
+	{Band3400, "3,4 GHz"},
+	{Band5700, "5,7 GHz"},
+	{Band10G, "10 GHz"},
+	{Band24G, "24 GHz"},
+	{Band47G, "47 GHz"},
+	{Band75G, "76 GHz"},
+	{Band123G, "122 GHz"},
+	{Band134G, "134 GHz"},
+	{Band241G, "248 GHz"},
+}
+
+// ediModes maps the mode codes of EDI QSO records to Cabrillo modes. Codes 3
+// and 4 are QSOs with a different mode in each direction; the sent mode is
+// used.
+var ediModes = map[string]string{
+	"0": "DG", // none
+	"1": "PH", // SSB
+	"2": "CW",
+	"3": "PH", // SSB sent, CW received
+	"4": "CW", // CW sent, SSB received
+	"5": "PH", // AM
+	"6": "FM",
+	"7": "RY",
+	"8": "DG", // SSTV
+	"9": "DG", // ATV
+}
+
+// cabrilloEDIModes maps Cabrillo modes to EDI mode codes. Other modes are
+// written as 0.
+var cabrilloEDIModes = map[string]string{
+	"PH": "1",
+	"CW": "2",
+	"FM": "6",
+	"RY": "7",
+}
+
+// ediHeaderKeys are the keys of the [REG1TEST;1] section in the order they
+// are written.
+var ediHeaderKeys = []string{
+	"TName", "TDate", "PCall", "PWWLo", "PExch", "PAdr1", "PAdr2", "PSect",
+	"PBand", "PClub", "RName", "RCall", "RAdr1", "RAdr2", "RPoCo", "RCity",
+	"RCoun", "RPhon", "RHBBS", "MOpe1", "MOpe2", "STXEq", "SPowe", "SRXEq",
+	"SAnte", "SAntH", "CQSOs", "CQSOP", "CWWLs", "CWWLB", "CExcs", "CExcB",
+	"CDXCs", "CDXCB", "CToSc", "CODXC",
+}
+
+// ediComputedKeys are the header keys WriteEDI computes from the QSOs. They
+// are dropped by ParseEDI.
+var ediComputedKeys = map[string]struct{}{
+	"PBAND": {},
+	"CQSOS": {},
+	"CQSOP": {},
+	"CWWLS": {},
+	"CWWLB": {},
+	"CEXCS": {},
+	"CEXCB": {},
+	"CDXCS": {},
+	"CDXCB": {},
+	"CODXC": {},
+}
+
+// ediExtensionPrefix prefixes the names of the extensible fields holding the
+// EDI header fields that have no equivalent in the Cabrillo header.
+const ediExtensionPrefix = "EDI-"
+
+// ParseEDI builds a log from an EDI (REG1TEST) file, the format used for IARU
+// Region 1 VHF and up contests. An EDI file holds the QSOs of a single band.
+//
+// The header fields TName, PCall, PWWLo, PClub, RName, RAdr1, RAdr2, RPoCo,
+// RCity, RCoun, RHBBS, MOpe1, MOpe2 and CToSc become the matching fields of
+// the log, PBand becomes CATEGORY-BAND and PSect becomes CATEGORY-OPERATOR.
+// The [Remarks] become the soapbox. Header fields without an equivalent, like
+// TDate or SPowe, are kept as extensible fields named EDI-<KEY> so WriteEDI
+// can write them back. The totals are dropped.
+//
+// Each QSO record becomes a QSO on the band designator of PBand. The sent
+// exchange is the sent serial number, PExch and PWWLo, the received exchange
+// is the received serial number, exchange and locator. The QSO points and the
+// new multiplier and dupe flags are dropped, as WriteEDI computes them.
+func ParseEDI(r io.Reader) (Log, error) {
+	l := Log{
+		Certificate: true, // Defaults to yes per the Cabrillo specification.
+	}
+	header := make(map[string]string)
+	var records []string
+	var reg1test bool
+
+	section := ""
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			section = strings.ToUpper(strings.TrimSuffix(strings.TrimPrefix(line, "["), "]"))
+			if i := strings.IndexByte(section, ';'); i != -1 {
+				section = section[:i]
+			}
+			reg1test = reg1test || section == "REG1TEST"
+			continue
+		}
+
+		switch section {
+		case "REG1TEST":
+			i := strings.IndexByte(line, '=')
+			if i == -1 {
+				continue
+			}
+			key := strings.ToUpper(strings.TrimSpace(line[:i]))
+			value := strings.TrimSpace(line[i+1:])
+			header[key] = value
+
+			if _, ok := ediComputedKeys[key]; ok || value == "" {
+				continue
+			}
+			switch key {
+			case "TNAME":
+				l.Contest = value
+			case "PCALL":
+				l.CallSign = value
+			case "PWWLO":
+				l.GridLocator = value
+			case "PCLUB":
+				l.Club = value
+			case "RNAME":
+				l.Name = value
+			case "RADR1", "RADR2":
+				l.Address.Address = append(l.Address.Address, value)
+			case "RPOCO":
+				l.Address.PostalCode = value
+			case "RCITY":
+				l.Address.City = value
+			case "RCOUN":
+				l.Address.Country = value
+			case "RHBBS":
+				l.Email = value
+			case "MOPE1", "MOPE2":
+				l.Operators = append(l.Operators, operatorsField(strings.ReplaceAll(value, ";", " "))...)
+			case "CTOSC":
+				score, err := strconv.Atoi(value)
+				if err != nil {
+					return Log{}, fmt.Errorf("parsing CToSc field: %w", err)
+				}
+				l.ClaimedScore = score
+			case "PSECT":
+				if op := ediOperatorCategory(value); op != "" {
+					l.Categories = append(l.Categories, Category{Name: CategoryOperator, Value: op})
+				}
+				l.AddExtensibleField(ediExtensionPrefix+key, value)
+			default:
+				l.AddExtensibleField(ediExtensionPrefix+key, value)
+			}
+		case "REMARKS":
+			if line != "" {
+				l.SoapBox = append(l.SoapBox, line)
+			}
+		case "QSORECORDS":
+			if line != "" {
+				records = append(records, line)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Log{}, err
+	}
+
+	if !reg1test {
+		return Log{}, errors.New("missing [REG1TEST;1] section")
+	}
+
+	band := ediBand(header["PBAND"])
+	if band == "" {
+		return Log{}, fmt.Errorf("unknown PBand %q", header["PBAND"])
+	}
+	l.Categories = append(l.Categories, Category{Name: CategoryBand, Value: band})
+
+	var freq string
+	for _, b := range adifBands {
+		if b.band == band {
+			freq = b.designator
+		}
+	}
+
+	l.QSOs = make([]QSO, 0, len(records))
+	for i, rec := range records {
+		q, err := ediQSO(rec, freq, header)
+		if err != nil {
+			return Log{}, fmt.Errorf("QSO record %d: %w", i+1, err)
+		}
+		l.QSOs = append(l.QSOs, q)
+	}
+
+	return l, nil
+}
+
+// ediQSO converts a QSO record of an EDI file to a QSO. The fields of the
+// record are date, time, callsign, mode code, sent RST, sent serial number,
+// received RST, received serial number, received exchange, received locator,
+// QSO points, new exchange, new locator, new DXCC and dupe.
+func ediQSO(record, freq string, header map[string]string) (QSO, error) {
+	fields := strings.Split(record, ";")
+	if len(fields) < 10 {
+		return QSO{}, fmt.Errorf("expected at least 10 fields, got %d", len(fields))
+	}
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+
+	q := QSO{Frequency: freq}
+
+	var err error
+	q.Timestamp, err = time.Parse("060102 1504", fields[0]+" "+fields[1])
+	if err != nil {
+		return QSO{}, fmt.Errorf("parsing date %q and time %q: %w", fields[0], fields[1], err)
+	}
+
+	var ok bool
+	if q.Mode, ok = ediModes[firstNonEmpty(fields[3], "0")]; !ok {
+		return QSO{}, fmt.Errorf("unknown mode code %q", fields[3])
+	}
+
+	q.TxInfo.Callsign = header["PCALL"]
+	q.RxInfo.Callsign = fields[2]
+	if q.RxInfo.Callsign == "" {
+		return QSO{}, errors.New("missing callsign")
+	}
+
+	// Reports that aren't RST are left empty like in ParseADIF.
+	q.TxInfo.SignalReport, _ = NewRST(fields[4])
+	q.RxInfo.SignalReport, _ = NewRST(fields[6])
+
+	q.TxInfo.Exchange = joinNonEmpty(fields[5], header["PEXCH"], header["PWWLO"])
+	q.RxInfo.Exchange = joinNonEmpty(fields[7], fields[8], fields[9])
+
+	return q, nil
+}
+
+// ediBand returns the band of a PBand field, which may also be a band
+// designator like "144" or "1.2G", or an empty string if it's unknown.
+func ediBand(pband string) string {
+	normalized := func(s string) string {
+		return strings.ToUpper(strings.ReplaceAll(strings.ReplaceAll(s, " ", ""), ",", "."))
+	}
+
+	pband = normalized(pband)
+	for _, b := range ediBands {
+		if normalized(b.edi) == pband {
+			return b.band
+		}
+	}
+	if band, ok := bandDesignators[pband]; ok {
+		return band
+	}
+	return ""
+}
+
+// ediOperatorCategory returns the CATEGORY-OPERATOR of a PSect field, which
+// varies by contest but usually starts with SINGLE, SO, MULTI or MO.
+func ediOperatorCategory(psect string) string {
+	psect = strings.ToUpper(psect)
+	switch {
+	case strings.Contains(psect, "CHECK"):
+		return "CHECKLOG"
+	case strings.HasPrefix(psect, "M"):
+		return "MULTI-OP"
+	case strings.HasPrefix(psect, "S"):
+		return "SINGLE-OP"
+	}
+	return ""
+}
+
+// WriteEDI writes the log as an EDI (REG1TEST) file. All QSOs must be on the
+// same band, which must be one used in IARU Region 1 VHF and up contests. Logs
+// covering several bands must be written to one file per band. X-QSOs are left
+// out.
+//
+// The header fields are taken from the log as described for ParseEDI, with
+// PSect defaulting to SINGLE or MULTI after CATEGORY-OPERATOR and TDate
+// defaulting to the dates of the first and the last QSO. Each QSO record
+// has:
+//
+//   - The serial numbers, which are the first field of the exchanges when it
+//     is a number.
+//   - The received locator, which is the last field of the received exchange
+//     when it is a Maidenhead locator, and the rest of the exchange.
+//   - The QSO points, which are 1 point per km of distance from the locator
+//     at the end of the sent exchange, or PWWLo, to the received locator. As
+//     in the IARU Region 1 rules, the distance is rounded down and 1 km is
+//     added, so 41.1 km scores 42 points. Dupes and QSOs without a locator
+//     score 0.
+//   - The new exchange, new locator (the first four characters) and new DXCC
+//     flags, and the dupe flag for stations worked before.
+//
+// The totals of the header are computed from the QSO records. CToSc is the
+// claimed score of the log, or the total of the QSO points if there is none.
+func WriteEDI(w io.Writer, l Log) error {
+	var band string
+	for _, q := range l.QSOs {
+		b := q.Band()
+		switch {
+		case b == "":
+			return fmt.Errorf("unknown band for frequency %q", q.Frequency)
+		case band == "":
+			band = b
+		case b != band:
+			return fmt.Errorf("QSOs on %s and %s: EDI files hold the QSOs of a single band", band, b)
+		}
+	}
+	if band == "" {
+		band = l.Category(CategoryBand)
+	}
+
+	var pband string
+	for _, b := range ediBands {
+		if strings.EqualFold(b.band, band) {
+			pband = b.edi
+		}
+	}
+	if pband == "" {
+		return fmt.Errorf("band %q can't be written to an EDI file", band)
+	}
+
+	header := map[string]string{
+		"TNAME": l.Contest,
+		"PCALL": l.CallSign,
+		"PWWLO": l.GridLocator,
+		"PSECT": ediOperatorSection(l.Category(CategoryOperator)),
+		"PBAND": pband,
+		"PCLUB": l.Club,
+		"RNAME": l.Name,
+		"RPOCO": l.Address.PostalCode,
+		"RCITY": l.Address.City,
+		"RCOUN": l.Address.Country,
+		"RHBBS": l.Email,
+		"MOPE1": strings.Join(l.Operators, ";"),
+	}
+	if len(l.QSOs) > 0 {
+		header["TDATE"] = l.QSOs[0].Timestamp.Format("20060102") + ";" + l.QSOs[len(l.QSOs)-1].Timestamp.Format("20060102")
+	}
+	if len(l.Address.Address) > 0 {
+		header["RADR1"] = l.Address.Address[0]
+		header["RADR2"] = strings.Join(l.Address.Address[1:], ", ")
+	}
+
+	// Extensible fields written by ParseEDI take precedence.
+	var others []ExtensibleField
+	for _, f := range l.ExtensibleFields {
+		key := strings.ToUpper(strings.TrimPrefix(f.Name, ediExtensionPrefix))
+		if !strings.HasPrefix(f.Name, ediExtensionPrefix) || len(f.Values) == 0 {
+			continue
+		}
+		if !isEDIHeaderKey(key) {
+			others = append(others, f)
+			continue
+		}
+		header[key] = f.Values[len(f.Values)-1]
+	}
+
+	records, totals := ediRecords(l, header["PWWLO"])
+	for key, value := range totals {
+		header[key] = value
+	}
+	if l.ClaimedScore != 0 {
+		header["CTOSC"] = strconv.Itoa(l.ClaimedScore)
+	}
+
+	ew := &errWriter{w: w}
+	ew.printf("[REG1TEST;1]\r\n")
+	for _, key := range ediHeaderKeys {
+		ew.printf("%s=%s\r\n", key, header[strings.ToUpper(key)])
+	}
+	for _, f := range others {
+		for _, v := range f.Values {
+			ew.printf("%s=%s\r\n", strings.TrimPrefix(f.Name, ediExtensionPrefix), v)
+		}
+	}
+	ew.printf("[Remarks]\r\n")
+	for _, s := range l.SoapBox {
+		ew.printf("%s\r\n", s)
+	}
+	ew.printf("[QSORecords;%d]\r\n", len(records))
+	for _, r := range records {
+		ew.printf("%s\r\n", r)
+	}
+	ew.printf("[END;]\r\n")
+
+	return ew.err
+}
+
+// ediRecords returns the QSO records of the log and the header fields with
+// the totals.
+func ediRecords(l Log, locator string) ([]string, map[string]string) {
+	var (
+		records      []string
+		qsos, points int
+		odx          string
+		odxDistance  = -1.0
+		dupes        = newDupeChecker(DupePerBand)
+		exchanges    = make(map[string]struct{})
+		squares      = make(map[string]struct{})
+		entities     = make(map[string]struct{})
+		entityDB     = NewEntityDB()
+	)
+
+	// claimNew returns "N" the first time key is seen.
+	claimNew := func(seen map[string]struct{}, key string) string {
+		if key == "" {
+			return ""
+		}
+		if _, ok := seen[key]; ok {
+			return ""
+		}
+		seen[key] = struct{}{}
+		return "N"
+	}
+
+	for _, q := range l.QSOs {
+		txSerial, txRest := splitSerial(q.TxInfo.Exchange)
+		rxSerial, rxExchange, rxLocator := ediExchange(q.RxInfo.Exchange)
+		txLocator := locator
+		if _, _, loc := ediExchange(txRest); loc != "" {
+			txLocator = loc
+		}
+
+		mode, ok := cabrilloEDIModes[strings.ToUpper(q.Mode)]
+		if !ok {
+			mode = "0"
+		}
+
+		var (
+			qsoPoints               int
+			newExch, newWWL, newDXC string
+			dupe                    string
+		)
+		if dupes.check(q) {
+			dupe = "D"
+		} else {
+			qsos++
+			if d, err := LocatorDistance(txLocator, rxLocator); err == nil {
+				qsoPoints = int(math.Floor(d)) + 1
+				if d > odxDistance {
+					odxDistance = d
+					odx = fmt.Sprintf("%s;%s;%d", q.RxInfo.Callsign, rxLocator, int(math.Floor(d)))
+				}
+			}
+			points += qsoPoints
+
+			newExch = claimNew(exchanges, strings.ToUpper(rxExchange))
+			if len(rxLocator) >= 4 {
+				newWWL = claimNew(squares, strings.ToUpper(rxLocator[:4]))
+			}
+			if e, err := entityDB.Lookup(q.RxInfo.Callsign); err == nil {
+				newDXC = claimNew(entities, e.Prefix)
+			}
+		}
+
+		var txRST, rxRST string
+		if q.TxInfo.SignalReport != (RST{}) {
+			txRST = q.TxInfo.SignalReport.String()
+		}
+		if q.RxInfo.SignalReport != (RST{}) {
+			rxRST = q.RxInfo.SignalReport.String()
+		}
+
+		records = append(records, strings.Join([]string{
+			q.Timestamp.Format("060102"),
+			q.Timestamp.Format("1504"),
+			q.RxInfo.Callsign,
+			mode,
+			txRST,
+			txSerial,
+			rxRST,
+			rxSerial,
+			rxExchange,
+			rxLocator,
+			strconv.Itoa(qsoPoints),
+			newExch,
+			newWWL,
+			newDXC,
+			dupe,
+		}, ";"))
+	}
+
+	return records, map[string]string{
+		"CQSOS": fmt.Sprintf("%d;1", qsos),
+		"CQSOP": strconv.Itoa(points),
+		"CWWLS": fmt.Sprintf("%d;0;1", len(squares)),
+		"CWWLB": "0",
+		"CEXCS": fmt.Sprintf("%d;0;1", len(exchanges)),
+		"CEXCB": "0",
+		"CDXCS": fmt.Sprintf("%d;0;1", len(entities)),
+		"CDXCB": "0",
+		"CTOSC": strconv.Itoa(points),
+		"CODXC": odx,
+	}
+}
+
+// ediExchange splits an exchange into the serial number, the locator at the
+// end and what's left in between. Missing parts are empty.
+func ediExchange(exchange string) (string, string, string) {
+	serial, rest := splitSerial(exchange)
+	fields := strings.Fields(rest)
+	var locator string
+	if n := len(fields); n > 0 && validLocator(fields[n-1]) {
+		locator = fields[n-1]
+		fields = fields[:n-1]
+	}
+	return serial, strings.Join(fields, " "), locator
+}
+
+// ediOperatorSection returns the PSect field for a CATEGORY-OPERATOR.
+func ediOperatorSection(category string) string {
+	switch strings.ToUpper(category) {
+	case "SINGLE-OP":
+		return "SINGLE"
+	case "MULTI-OP":
+		return "MULTI"
+	case "CHECKLOG":
+		return "CHECK"
+	}
+	return ""
+}
+
+// isEDIHeaderKey returns true if key is one of ediHeaderKeys in upper case.
+func isEDIHeaderKey(key string) bool {
+	for _, k := range ediHeaderKeys {
+		if strings.ToUpper(k) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// LocatorDistance returns the great circle distance in km between the
+// centers of two Maidenhead locators of four or six characters, like JO62 or
+// JO62QM.
+func LocatorDistance(from, to string) (float64, error) {
+	lat1, lon1, err := locatorPosition(from)
+	if err != nil {
+		return 0, err
+	}
+	lat2, lon2, err := locatorPosition(to)
+	if err != nil {
+		return 0, err
+	}
+
+	const earthRadius = 6371.291 // km, as in the IARU Region 1 VHF contest rules
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat, dLon := rad(lat2-lat1), rad(lon2-lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(lat1))*math.Cos(rad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a))), nil
+}
+
+// locatorPosition returns the latitude and longitude in degrees of the center
+// of a Maidenhead locator.
+func locatorPosition(locator string) (float64, float64, error) {
+	loc := strings.ToUpper(strings.TrimSpace(locator))
+	if !validLocator(loc) {
+		return 0, 0, fmt.Errorf("invalid locator %q", locator)
+	}
+
+	lon := float64(loc[0]-'A')*20 - 180 + float64(loc[2]-'0')*2
+	lat := float64(loc[1]-'A')*10 - 90 + float64(loc[3]-'0')
+	if len(loc) == 6 {
+		lon += float64(loc[4]-'A')*2/24 + 1.0/24
+		lat += float64(loc[5]-'A')/24 + 1.0/48
+	} else {
+		lon++
+		lat += 0.5
+	}
+	return lat, lon, nil
+}
+
+// validLocator returns true if locator is a Maidenhead locator of four or six
+// characters.
+func validLocator(locator string) bool {
+	loc := strings.ToUpper(locator)
+	if len(loc) != 4 && len(loc) != 6 {
+		return false
+	}
+	for i := 0; i < len(loc); i++ {
+		c := loc[i]
+		switch i {
+		case 0, 1:
+			if c < 'A' || c > 'R' {
+				return false
+			}
+		case 2, 3:
+			if c < '0' || c > '9' {
+				return false
+			}
+		default:
+			if c < 'A' || c > 'X' {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,169 @@
+package cabrillo
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEDI(t *testing.T) {
+	fh, err := os.Open("testdata/contest.edi")
+	require.NoError(t, err)
+	defer fh.Close()
+
+	l, err := ParseEDI(fh)
+	require.NoError(t, err)
+
+	require.Equal(t, "IARU Region 1 VHF Contest", l.Contest)
+	require.Equal(t, "DL0ABC", l.CallSign)
+	require.Equal(t, "JO62QM", l.GridLocator)
+	require.Equal(t, "DARC", l.Club)
+	require.Equal(t, "Max Mustermann", l.Name)
+	require.Equal(t, Address{Address: []string{"Hauptstr. 1"}, City: "Berlin", PostalCode: "10115", Country: "Germany"}, l.Address)
+	require.Equal(t, "dl1abc@example.com", l.Email)
+	require.Equal(t, []string{"DL1ABC", "DL2XYZ"}, l.Operators)
+	require.Equal(t, 493, l.ClaimedScore)
+	require.Equal(t, "MULTI-OP", l.Category(CategoryOperator))
+	require.Equal(t, Band2M, l.Category(CategoryBand))
+	require.Equal(t, []string{"Thanks for all QSOs."}, l.SoapBox)
+	require.Equal(t, []string{"500"}, l.ExtendedField("EDI-SPOWE"))
+	require.Equal(t, []string{"20230902;20230903"}, l.ExtendedField("EDI-TDATE"))
+	require.Nil(t, l.ExtendedField("EDI-CQSOS"))
+
+	require.Len(t, l.QSOs, 4)
+	require.Equal(t, QSO{
+		Frequency: "144",
+		Mode:      "CW",
+		Timestamp: time.Date(2023, time.September, 2, 14, 2, 0, 0, time.UTC),
+		TxInfo:    Info{Callsign: "DL0ABC", SignalReport: RST{5, 9, 9}, Exchange: "001 JO62QM"},
+		RxInfo:    Info{Callsign: "OK1KIM", SignalReport: RST{5, 9, 9}, Exchange: "012 JO60JJ"},
+	}, l.QSOs[0])
+	require.Equal(t, "FM", l.QSOs[3].Mode)
+
+	t.Run("errors", func(t *testing.T) {
+		const header = "[REG1TEST;1]\nPCall=DL0ABC\nPBand=432 MHz\n"
+		tests := []struct {
+			input string
+			err   string
+		}{
+			{"PCall=DL0ABC\n", "missing [REG1TEST;1] section"},
+			{"[REG1TEST;1]\nPCall=DL0ABC\nPBand=14 MHz\n", `unknown PBand "14 MHz"`},
+			{header + "[QSORecords;1]\n230902;1402;OK1KIM;2\n", "QSO record 1: expected at least 10 fields, got 4"},
+			{header + "[QSORecords;1]\n230902;1402;OK1KIM;X;599;001;599;012;;JO60JJ;;;;;\n", `QSO record 1: unknown mode code "X"`},
+			{header + "[QSORecords;1]\n230902;1402;;2;599;001;599;012;;JO60JJ;;;;;\n", "QSO record 1: missing callsign"},
+		}
+		for _, tt := range tests {
+			_, err := ParseEDI(strings.NewReader(tt.input))
+			require.EqualError(t, err, tt.err)
+		}
+	})
+
+	t.Run("band designator", func(t *testing.T) {
+		l, err := ParseEDI(strings.NewReader("[REG1TEST;1]\nPCall=DL0ABC\nPBand=1.3GHz\n"))
+		require.NoError(t, err)
+		require.Equal(t, Band1200, l.Category(CategoryBand))
+	})
+}
+
+func TestWriteEDI(t *testing.T) {
+	data, err := os.ReadFile("testdata/contest.edi")
+	require.NoError(t, err)
+
+	t.Run("round trip", func(t *testing.T) {
+		l, err := ParseEDI(strings.NewReader(string(data)))
+		require.NoError(t, err)
+
+		var buf strings.Builder
+		require.NoError(t, WriteEDI(&buf, l))
+		require.Equal(t, string(data), buf.String())
+	})
+
+	t.Run("through cabrillo", func(t *testing.T) {
+		l, err := ParseEDI(strings.NewReader(string(data)))
+		require.NoError(t, err)
+
+		var cabrillo strings.Builder
+		require.NoError(t, WriteLog(&cabrillo, l))
+		converted, err := ParseLog(strings.NewReader(cabrillo.String()), WithExchangeFields(2))
+		require.NoError(t, err)
+
+		var buf strings.Builder
+		require.NoError(t, WriteEDI(&buf, converted))
+		require.Equal(t, string(data), buf.String())
+	})
+
+	t.Run("cabrillo log", func(t *testing.T) {
+		l := Log{
+			CallSign:    "W1AW",
+			Contest:     "ARRL-VHF-SEP",
+			GridLocator: "FN31",
+			Categories:  []Category{{Name: CategoryOperator, Value: "SINGLE-OP"}},
+			QSOs: mustQSOs(t,
+				"QSO: 432 PH 2023-09-09 1800 W1AW 59 FN31 K1TEO 59 FN31",
+				"QSO: 432 DG 2023-09-09 1805 W1AW 59 FN31 VE1SKY 59 FN74",
+			),
+		}
+
+		var buf strings.Builder
+		require.NoError(t, WriteEDI(&buf, l))
+		out := buf.String()
+		require.Contains(t, out, "PSect=SINGLE\r\n")
+		require.Contains(t, out, "PBand=432 MHz\r\n")
+		require.Contains(t, out, "TDate=20230909;20230909\r\n")
+		require.Contains(t, out, "[QSORecords;2]\r\n230909;1800;K1TEO;1;59;;59;;;FN31;1;;N;N;\r\n230909;1805;VE1SKY;0;59;;59;;;FN74;")
+	})
+
+	t.Run("points", func(t *testing.T) {
+		l := Log{
+			CallSign: "DL0ABC",
+			QSOs: mustQSOs(t,
+				// 41.07 km.
+				"QSO: 144 CW 2023-09-02 1402 DL0ABC 599 JO62QM OK1ABC 599 JO62KH",
+				"QSO: 144 CW 2023-09-02 1403 DL0ABC 599 JO62QM DL1ABC 599 JO62QM",
+			),
+		}
+
+		var buf strings.Builder
+		require.NoError(t, WriteEDI(&buf, l))
+		require.Contains(t, buf.String(), "\r\n230902;1402;OK1ABC;2;599;;599;;;JO62KH;42;")
+		require.Contains(t, buf.String(), "\r\n230902;1403;DL1ABC;2;599;;599;;;JO62QM;1;")
+		require.Contains(t, buf.String(), "\r\nCODXC=OK1ABC;JO62KH;41\r\n")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var buf strings.Builder
+		err := WriteEDI(&buf, Log{QSOs: mustQSOs(t,
+			"QSO: 144 CW 2023-09-02 1402 DL0ABC 599 001 OK1KIM 599 012",
+			"QSO: 432 CW 2023-09-02 1410 DL0ABC 599 002 OK1KIM 599 013",
+		)})
+		require.EqualError(t, err, "QSOs on 2M and 432: EDI files hold the QSOs of a single band")
+
+		err = WriteEDI(&buf, Log{QSOs: mustQSOs(t,
+			"QSO: 14025 CW 2023-09-02 1402 DL0ABC 599 001 OK1KIM 599 012",
+		)})
+		require.EqualError(t, err, `band "20M" can't be written to an EDI file`)
+	})
+}
+
+func TestLocatorDistance(t *testing.T) {
+	tests := []struct {
+		from, to string
+		km       float64
+	}{
+		{"JO62QM", "JO62QM", 0},
+		{"JO62QM", "JO60JJ", 240},
+		{"JN58TD", "JO62QM", 502},
+		{"FN31", "JO62", 6240},
+	}
+	for _, tt := range tests {
+		d, err := LocatorDistance(tt.from, tt.to)
+		require.NoError(t, err)
+		require.InDelta(t, tt.km, d, 1, "%s to %s", tt.from, tt.to)
+	}
+
+	_, err := LocatorDistance("JO62QM", "JZ62")
+	require.EqualError(t, err, `invalid locator "JZ62"`)
+}
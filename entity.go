@@ -0,0 +1,326 @@
+package cabrillo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownEntity is returned when a callsign can't be resolved to an entity.
+var ErrUnknownEntity = errors.New("unknown entity")
+
+// Entity is a DXCC entity, commonly referred to as a "country" in contest rules.
+type Entity struct {
+	Name      string
+	Prefix    string
+	Continent string
+	CQZone    int
+	ITUZone   int
+}
+
+// EntityDB resolves callsigns to entities by matching the longest known prefix.
+// Individual callsigns can be mapped to an entity as well, which is how
+// exceptions to the prefix rules are handled.
+type EntityDB struct {
+	prefixes map[string]Entity
+	calls    map[string]Entity
+}
+
+// NewEntityDB returns an EntityDB populated with the built-in prefix table. The
+// built-in table covers the primary prefixes of the common entities. For full
+// coverage, load a cty.dat file with ParseCtyDat instead.
+func NewEntityDB() *EntityDB {
+	db := newEntityDB()
+	for _, v := range builtinEntities {
+		db.Add(v.entity, strings.Fields(v.prefixes)...)
+	}
+	for _, p := range russianPrefixes("134567") {
+		db.Add(entityEuropeanRussia, p)
+	}
+	for _, p := range russianPrefixes("2") {
+		db.Add(entityKaliningrad, p)
+	}
+	for _, p := range russianPrefixes("890") {
+		db.Add(entityAsiaticRussia, p)
+	}
+
+	return db
+}
+
+func newEntityDB() *EntityDB {
+	return &EntityDB{
+		prefixes: make(map[string]Entity),
+		calls:    make(map[string]Entity),
+	}
+}
+
+// Add maps the prefixes to the entity. A prefix starting with "=" is treated as
+// a full callsign rather than a prefix.
+func (db *EntityDB) Add(e Entity, prefixes ...string) {
+	for _, p := range prefixes {
+		p = strings.ToUpper(p)
+		if strings.HasPrefix(p, "=") {
+			db.calls[strings.TrimPrefix(p, "=")] = e
+			continue
+		}
+		db.prefixes[p] = e
+	}
+}
+
+// Lookup resolves the callsign to an entity. Portable designators are taken
+// into account, so "KH6/W1AW" and "W1AW/KH6" both resolve to Hawaii while
+// "W1AW/P" resolves to the United States. Maritime and aeronautical mobile
+// stations don't belong to any entity.
+func (db *EntityDB) Lookup(call string) (Entity, error) {
+	call = strings.ToUpper(strings.TrimSpace(call))
+	if e, ok := db.calls[call]; ok {
+		return e, nil
+	}
+
+	key, err := db.lookupKey(call)
+	if err != nil {
+		return Entity{}, err
+	}
+
+	if e, ok := db.calls[key]; ok {
+		return e, nil
+	}
+
+	for i := len(key); i > 0; i-- {
+		if e, ok := db.prefixes[key[:i]]; ok {
+			return e, nil
+		}
+	}
+
+	return Entity{}, fmt.Errorf("%w: %q", ErrUnknownEntity, call)
+}
+
+// waeParents maps the prefixes of the entities that count as countries on
+// the WAE and CQ lists only to the prefix of the DXCC entity they belong to.
+// cty.dat marks these entities with a "*".
+var waeParents = map[string]string{
+	"4U1V": "OE", // Vienna International Centre
+	"GM/s": "GM", // Shetland Islands
+	"IG9":  "I",  // African Italy
+	"IT9":  "I",  // Sicily
+	"JW/b": "JW", // Bear Island
+	"TA1":  "TA", // European Turkey
+}
+
+// dxccPrefix returns the prefix of the DXCC entity of the entity, which is
+// the entity itself unless it's only on the WAE and CQ lists.
+func dxccPrefix(e Entity) string {
+	if parent, ok := waeParents[e.Prefix]; ok {
+		return parent
+	}
+	return e.Prefix
+}
+
+// ignoredDesignators are the portable designators that don't change the entity
+// of the station.
+var ignoredDesignators = map[string]struct{}{
+	"A":    {},
+	"LH":   {},
+	"M":    {},
+	"P":    {},
+	"QRP":  {},
+	"QRPP": {},
+}
+
+// lookupKey strips the portable designators from the call that don't affect
+// the entity and returns the string to match prefixes against.
+func (db *EntityDB) lookupKey(call string) (string, error) {
+	var parts []string
+	for _, p := range strings.Split(call, "/") {
+		if p == "" {
+			continue
+		}
+		if p == "MM" || p == "AM" {
+			return "", fmt.Errorf("%w: %q is maritime or aeronautical mobile", ErrUnknownEntity, call)
+		}
+		if _, ok := ignoredDesignators[p]; ok {
+			continue
+		}
+		parts = append(parts, p)
+	}
+
+	switch len(parts) {
+	case 0:
+		return "", fmt.Errorf("%w: %q", ErrUnknownEntity, call)
+	case 1:
+		return parts[0], nil
+	}
+
+	// A single digit changes the call area: W1AW/4 becomes W4AW.
+	if isDigits(parts[1]) && len(parts[1]) == 1 {
+		return replaceCallArea(parts[0], parts[1]), nil
+	}
+	if isDigits(parts[0]) && len(parts[0]) == 1 {
+		return replaceCallArea(parts[1], parts[0]), nil
+	}
+
+	// Otherwise the shorter part is the prefix of the location.
+	switch {
+	case len(parts[1]) < len(parts[0]):
+		return parts[1], nil
+	case len(parts[0]) < len(parts[1]):
+		return parts[0], nil
+	}
+
+	// Both parts have the same length. A part that is a known callsign is the
+	// station's own call, and a part that is a known prefix is the location.
+	// Failing that, the location is the first part, as it's usually written.
+	_, firstIsCall := db.calls[parts[0]]
+	_, secondIsCall := db.calls[parts[1]]
+	_, firstIsPrefix := db.prefixes[parts[0]]
+	_, secondIsPrefix := db.prefixes[parts[1]]
+	switch {
+	case firstIsCall && !secondIsCall:
+		return parts[1], nil
+	case secondIsCall && !firstIsCall:
+		return parts[0], nil
+	case secondIsPrefix && !firstIsPrefix:
+		return parts[1], nil
+	}
+	return parts[0], nil
+}
+
+// replaceCallArea replaces the digit of the call area in the callsign.
+func replaceCallArea(call, digit string) string {
+	for i := 0; i < len(call); i++ {
+		// The first character can be a digit (e.g. 4X6TT). Skip it.
+		if i > 0 && call[i] >= '0' && call[i] <= '9' {
+			return call[:i] + digit + call[i+1:]
+		}
+	}
+	return call
+}
+
+func isDigits(str string) bool {
+	if str == "" {
+		return false
+	}
+	for _, c := range str {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseCtyDat parses an entity database in the cty.dat format maintained at
+// https://www.country-files.com/ and used by most logging programs.
+func ParseCtyDat(r io.Reader) (*EntityDB, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	db := newEntityDB()
+	for _, record := range strings.Split(string(b), ";") {
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+
+		fields := strings.SplitN(record, ":", 9)
+		if len(fields) != 9 {
+			return nil, fmt.Errorf("invalid cty.dat record %q", strings.TrimSpace(record))
+		}
+
+		e := Entity{
+			Name:      strings.TrimSpace(fields[0]),
+			Prefix:    strings.TrimPrefix(strings.TrimSpace(fields[7]), "*"),
+			Continent: strings.TrimSpace(fields[3]),
+		}
+		e.CQZone, err = strconv.Atoi(strings.TrimSpace(fields[1]))
+		if err != nil {
+			return nil, fmt.Errorf("parsing CQ zone of %q: %w", e.Name, err)
+		}
+		e.ITUZone, err = strconv.Atoi(strings.TrimSpace(fields[2]))
+		if err != nil {
+			return nil, fmt.Errorf("parsing ITU zone of %q: %w", e.Name, err)
+		}
+
+		db.Add(e, e.Prefix)
+		for _, alias := range strings.Split(fields[8], ",") {
+			alias = strings.TrimSpace(alias)
+			if alias == "" {
+				continue
+			}
+			prefix, override, err := parseCtyAlias(alias, e)
+			if err != nil {
+				return nil, fmt.Errorf("parsing alias %q of %q: %w", alias, e.Name, err)
+			}
+			db.Add(override, prefix)
+		}
+	}
+
+	return db, nil
+}
+
+// ctyOverrideDelimiters maps the opening character of an override in a cty.dat
+// alias to its closing character.
+var ctyOverrideDelimiters = map[byte]byte{
+	'(': ')', // CQ zone
+	'[': ']', // ITU zone
+	'<': '>', // latitude/longitude
+	'{': '}', // continent
+	'~': '~', // time offset
+}
+
+// parseCtyAlias parses an alias from a cty.dat record. An alias can override
+// the zones and continent of the entity, e.g. "=VE2IM(2)[4]" or "KG4{NA}".
+func parseCtyAlias(alias string, e Entity) (string, Entity, error) {
+	i := strings.IndexAny(alias, "([<{~")
+	if i == -1 {
+		return alias, e, nil
+	}
+
+	prefix := alias[:i]
+	rest := alias[i:]
+	for rest != "" {
+		closing, ok := ctyOverrideDelimiters[rest[0]]
+		if !ok {
+			return "", Entity{}, fmt.Errorf("unexpected character %q", rest[0])
+		}
+		end := strings.IndexByte(rest[1:], closing)
+		if end == -1 {
+			return "", Entity{}, fmt.Errorf("missing closing %q", closing)
+		}
+		value := rest[1 : end+1]
+		rest = rest[end+2:]
+
+		var err error
+		switch closing {
+		case ')':
+			e.CQZone, err = strconv.Atoi(value)
+		case ']':
+			e.ITUZone, err = strconv.Atoi(value)
+		case '}':
+			e.Continent = value
+		}
+		if err != nil {
+			return "", Entity{}, err
+		}
+	}
+
+	return prefix, e, nil
+}
+
+// russianPrefixes returns the Russian prefixes for the given call area digits.
+// The digit determines which of the Russian entities a station is in.
+func russianPrefixes(digits string) []string {
+	var prefixes []string
+	for _, d := range digits {
+		prefixes = append(prefixes, "R"+string(d), "U"+string(d))
+		for l := 'A'; l <= 'Z'; l++ {
+			prefixes = append(prefixes, "R"+string(l)+string(d))
+		}
+		for l := 'A'; l <= 'I'; l++ {
+			prefixes = append(prefixes, "U"+string(l)+string(d))
+		}
+	}
+	return prefixes
+}
@@ -0,0 +1,291 @@
+package cabrillo
+
+var (
+	entityEuropeanRussia = Entity{"European Russia", "UA", "EU", 16, 29}
+	entityKaliningrad    = Entity{"Kaliningrad", "UA2", "EU", 15, 29}
+	entityAsiaticRussia  = Entity{"Asiatic Russia", "UA9", "AS", 17, 30}
+)
+
+// builtinEntities is the prefix table used by NewEntityDB. It includes the WAE
+// entities (e.g. Sicily) that count as countries in the CQ contests. Russian
+// prefixes depend on the call area and are generated by russianPrefixes.
+var builtinEntities = []struct {
+	entity   Entity
+	prefixes string
+}{
+	// North America
+	{Entity{"United States", "K", "NA", 5, 8}, "AA AB AC AD AE AF AG AI AJ AK K N W"},
+	{Entity{"Alaska", "KL", "NA", 1, 1}, "AL KL NL WL"},
+	{Entity{"Canada", "VE", "NA", 5, 9}, "CF CG CH CI CJ CK CY VA VB VC VD VE VG VO VX VY XJ XK XL XM XN XO"},
+	{Entity{"Sable Island", "CY0", "NA", 5, 9}, "CY0"},
+	{Entity{"St. Paul Island", "CY9", "NA", 5, 9}, "CY9"},
+	{Entity{"St. Pierre & Miquelon", "FP", "NA", 5, 9}, "FP"},
+	{Entity{"Greenland", "OX", "NA", 40, 5}, "OX XP"},
+	{Entity{"Bermuda", "VP9", "NA", 5, 11}, "VP9"},
+	{Entity{"Mexico", "XE", "NA", 6, 10}, "4A 4B 4C 6D 6E 6F 6G 6H 6I 6J XA XB XC XD XE XF XG XH XI"},
+	{Entity{"Revillagigedo", "XF4", "NA", 6, 10}, "XF4"},
+	{Entity{"Guatemala", "TG", "NA", 7, 11}, "TD TG"},
+	{Entity{"Belize", "V3", "NA", 7, 11}, "V3"},
+	{Entity{"Honduras", "HR", "NA", 7, 11}, "HQ HR"},
+	{Entity{"El Salvador", "YS", "NA", 7, 11}, "HU YS"},
+	{Entity{"Nicaragua", "YN", "NA", 7, 11}, "H6 H7 HT YN"},
+	{Entity{"Costa Rica", "TI", "NA", 7, 11}, "TE TI"},
+	{Entity{"Panama", "HP", "NA", 7, 11}, "3E 3F H3 H8 H9 HO HP"},
+	{Entity{"Cuba", "CM", "NA", 8, 11}, "CL CM CO T4"},
+	{Entity{"Bahamas", "C6", "NA", 8, 11}, "C6"},
+	{Entity{"Cayman Islands", "ZF", "NA", 8, 11}, "ZF"},
+	{Entity{"Jamaica", "6Y", "NA", 8, 11}, "6Y"},
+	{Entity{"Haiti", "HH", "NA", 8, 11}, "4V HH"},
+	{Entity{"Dominican Republic", "HI", "NA", 8, 11}, "HI"},
+	{Entity{"Puerto Rico", "KP4", "NA", 8, 11}, "KP3 KP4 NP3 NP4 WP3 WP4"},
+	{Entity{"US Virgin Islands", "KP2", "NA", 8, 11}, "KP2 NP2 WP2"},
+	{Entity{"British Virgin Islands", "VP2V", "NA", 8, 11}, "VP2V"},
+	{Entity{"Anguilla", "VP2E", "NA", 8, 11}, "VP2E"},
+	{Entity{"Montserrat", "VP2M", "NA", 8, 11}, "VP2M"},
+	{Entity{"Turks & Caicos Islands", "VP5", "NA", 8, 11}, "VP5 VQ5"},
+	{Entity{"St. Kitts & Nevis", "V4", "NA", 8, 11}, "V4"},
+	{Entity{"Antigua & Barbuda", "V2", "NA", 8, 11}, "V2"},
+	{Entity{"Guadeloupe", "FG", "NA", 8, 11}, "FG"},
+	{Entity{"Saint Martin", "FS", "NA", 8, 11}, "FS"},
+	{Entity{"Saint Barthelemy", "FJ", "NA", 8, 11}, "FJ"},
+	{Entity{"Sint Maarten", "PJ7", "NA", 8, 11}, "PJ7"},
+	{Entity{"Saba & St. Eustatius", "PJ5", "NA", 8, 11}, "PJ5 PJ6"},
+	{Entity{"Dominica", "J7", "NA", 8, 11}, "J7"},
+	{Entity{"Martinique", "FM", "NA", 8, 11}, "FM"},
+	{Entity{"St. Lucia", "J6", "NA", 8, 11}, "J6"},
+	{Entity{"St. Vincent", "J8", "NA", 8, 11}, "J8"},
+	{Entity{"Barbados", "8P", "NA", 8, 11}, "8P"},
+	{Entity{"Grenada", "J3", "NA", 8, 11}, "J3"},
+
+	// South America
+	{Entity{"Trinidad & Tobago", "9Y", "SA", 9, 11}, "9Y 9Z"},
+	{Entity{"Aruba", "P4", "SA", 9, 11}, "P4"},
+	{Entity{"Curacao", "PJ2", "SA", 9, 11}, "PJ2"},
+	{Entity{"Bonaire", "PJ4", "SA", 9, 11}, "PJ4"},
+	{Entity{"Venezuela", "YV", "SA", 9, 12}, "4M YV YW YX YY"},
+	{Entity{"Colombia", "HK", "SA", 9, 12}, "5J 5K HJ HK"},
+	{Entity{"Guyana", "8R", "SA", 9, 12}, "8R"},
+	{Entity{"Suriname", "PZ", "SA", 9, 12}, "PZ"},
+	{Entity{"French Guiana", "FY", "SA", 9, 12}, "FY"},
+	{Entity{"Ecuador", "HC", "SA", 10, 12}, "HC HD"},
+	{Entity{"Galapagos Islands", "HC8", "SA", 10, 12}, "HC8 HD8"},
+	{Entity{"Peru", "OA", "SA", 10, 12}, "4T OA OB OC"},
+	{Entity{"Bolivia", "CP", "SA", 10, 12}, "CP"},
+	{Entity{"Brazil", "PY", "SA", 11, 15}, "PP PQ PR PS PT PU PV PW PX PY ZV ZW ZX ZY ZZ"},
+	{Entity{"Paraguay", "ZP", "SA", 11, 14}, "ZP"},
+	{Entity{"Chile", "CE", "SA", 12, 14}, "3G CA CB CC CD CE XQ XR"},
+	{Entity{"Uruguay", "CX", "SA", 13, 14}, "CV CW CX"},
+	{Entity{"Argentina", "LU", "SA", 13, 14}, "AY AZ L2 L3 L4 L5 L6 L7 L8 L9 LO LP LQ LR LS LT LU LV LW"},
+	{Entity{"Falkland Islands", "VP8", "SA", 13, 16}, "VP8"},
+
+	// Europe
+	{Entity{"England", "G", "EU", 14, 27}, "2E G M"},
+	{Entity{"Scotland", "GM", "EU", 14, 27}, "2M GM GS MM MS"},
+	{Entity{"Wales", "GW", "EU", 14, 27}, "2W GC GW MC MW"},
+	{Entity{"Northern Ireland", "GI", "EU", 14, 27}, "2I GI GN MI MN"},
+	{Entity{"Isle of Man", "GD", "EU", 14, 27}, "2D GD GT MD MT"},
+	{Entity{"Jersey", "GJ", "EU", 14, 27}, "2J GH GJ MH MJ"},
+	{Entity{"Guernsey", "GU", "EU", 14, 27}, "2U GP GU MP MU"},
+	{Entity{"Ireland", "EI", "EU", 14, 27}, "EI EJ"},
+	{Entity{"France", "F", "EU", 14, 27}, "F TH TM"},
+	{Entity{"Corsica", "TK", "EU", 15, 28}, "TK"},
+	{Entity{"Monaco", "3A", "EU", 14, 27}, "3A"},
+	{Entity{"Andorra", "C3", "EU", 14, 27}, "C3"},
+	{Entity{"Belgium", "ON", "EU", 14, 27}, "ON OO OP OQ OR OS OT"},
+	{Entity{"Netherlands", "PA", "EU", 14, 27}, "PA PB PC PD PE PF PG PH PI"},
+	{Entity{"Luxembourg", "LX", "EU", 14, 27}, "LX"},
+	{Entity{"Spain", "EA", "EU", 14, 37}, "AM AN AO EA EB EC ED EE EF EG EH"},
+	{Entity{"Balearic Islands", "EA6", "EU", 14, 37}, "AM6 AN6 AO6 EA6 EB6 EC6 ED6 EE6 EF6 EG6 EH6"},
+	{Entity{"Portugal", "CT", "EU", 14, 37}, "CQ CR CS CT"},
+	{Entity{"Azores", "CU", "EU", 14, 36}, "CQ8 CR8 CS8 CT8 CU"},
+	{Entity{"Gibraltar", "ZB", "EU", 14, 37}, "ZB ZG"},
+	{Entity{"Germany", "DL", "EU", 14, 28}, "DA DB DC DD DE DF DG DH DI DJ DK DL DM DN DO DP DQ DR Y2 Y3 Y4 Y5 Y6 Y7 Y8 Y9"},
+	{Entity{"Switzerland", "HB", "EU", 14, 28}, "HB HE"},
+	{Entity{"Liechtenstein", "HB0", "EU", 14, 28}, "HB0 HE0"},
+	{Entity{"Austria", "OE", "EU", 15, 28}, "OE"},
+	{Entity{"Italy", "I", "EU", 15, 28}, "I"},
+	{Entity{"Sardinia", "IS", "EU", 15, 28}, "IM0 IS0"},
+	{Entity{"Sicily", "IT9", "EU", 15, 28}, "IB9 ID9 IE9 IF9 II9 IJ9 IO9 IQ9 IR9 IT9 IU9 IW9"},
+	{Entity{"San Marino", "T7", "EU", 15, 28}, "T7"},
+	{Entity{"Vatican", "HV", "EU", 15, 28}, "HV"},
+	{Entity{"Malta", "9H", "EU", 15, 28}, "9H"},
+	{Entity{"Denmark", "OZ", "EU", 14, 18}, "5P 5Q OU OV OW OZ"},
+	{Entity{"Faroe Islands", "OY", "EU", 14, 18}, "OY"},
+	{Entity{"Norway", "LA", "EU", 14, 18}, "LA LB LC LD LE LF LG LH LI LJ LK LL LM LN"},
+	{Entity{"Svalbard", "JW", "EU", 40, 18}, "JW"},
+	{Entity{"Jan Mayen", "JX", "EU", 40, 18}, "JX"},
+	{Entity{"Sweden", "SM", "EU", 14, 18}, "7S 8S SA SB SC SD SE SF SG SH SI SJ SK SL SM"},
+	{Entity{"Finland", "OH", "EU", 15, 18}, "OF OG OH OI"},
+	{Entity{"Aland Islands", "OH0", "EU", 15, 18}, "OF0 OG0 OH0 OI0"},
+	{Entity{"Market Reef", "OJ0", "EU", 15, 18}, "OJ0"},
+	{Entity{"Iceland", "TF", "EU", 40, 17}, "TF"},
+	{Entity{"Estonia", "ES", "EU", 15, 29}, "ES"},
+	{Entity{"Latvia", "YL", "EU", 15, 29}, "YL"},
+	{Entity{"Lithuania", "LY", "EU", 15, 29}, "LY"},
+	{Entity{"Poland", "SP", "EU", 15, 28}, "3Z HF SN SO SP SQ SR"},
+	{Entity{"Czech Republic", "OK", "EU", 15, 28}, "OK OL"},
+	{Entity{"Slovak Republic", "OM", "EU", 15, 28}, "OM"},
+	{Entity{"Hungary", "HA", "EU", 15, 28}, "HA HG"},
+	{Entity{"Slovenia", "S5", "EU", 15, 28}, "S5"},
+	{Entity{"Croatia", "9A", "EU", 15, 28}, "9A"},
+	{Entity{"Bosnia-Herzegovina", "E7", "EU", 15, 28}, "E7"},
+	{Entity{"Serbia", "YU", "EU", 15, 28}, "YT YU"},
+	{Entity{"Montenegro", "4O", "EU", 15, 28}, "4O"},
+	{Entity{"Kosovo", "Z6", "EU", 15, 28}, "Z6"},
+	{Entity{"North Macedonia", "Z3", "EU", 15, 28}, "Z3"},
+	{Entity{"Albania", "ZA", "EU", 15, 28}, "ZA"},
+	{Entity{"Greece", "SV", "EU", 20, 28}, "J4 SV SW SX SY SZ"},
+	{Entity{"Crete", "SV9", "EU", 20, 28}, "J49 SV9 SW9 SX9 SY9 SZ9"},
+	{Entity{"Dodecanese", "SV5", "EU", 20, 28}, "J45 SV5 SW5 SX5 SY5 SZ5"},
+	{Entity{"Bulgaria", "LZ", "EU", 20, 28}, "LZ"},
+	{Entity{"Romania", "YO", "EU", 20, 28}, "YO YP YQ YR"},
+	{Entity{"Moldova", "ER", "EU", 16, 29}, "ER"},
+	{Entity{"Ukraine", "UR", "EU", 16, 29}, "EM EN EO UR US UT UU UV UW UX UY UZ"},
+	{Entity{"Belarus", "EU", "EU", 16, 29}, "EU EV EW"},
+	{Entity{"European Turkey", "TA1", "EU", 20, 39}, "TA1 TB1 TC1 YM1"},
+
+	// Asia
+	{Entity{"Georgia", "4L", "AS", 21, 29}, "4L"},
+	{Entity{"Armenia", "EK", "AS", 21, 29}, "EK"},
+	{Entity{"Azerbaijan", "4J", "AS", 21, 29}, "4J 4K"},
+	{Entity{"Asiatic Turkey", "TA", "AS", 20, 39}, "TA TB TC YM"},
+	{Entity{"Cyprus", "5B", "AS", 20, 39}, "5B C4 H2 P3"},
+	{Entity{"UK Sov. Base Areas on Cyprus", "ZC4", "AS", 20, 39}, "ZC4"},
+	{Entity{"Israel", "4X", "AS", 20, 39}, "4X 4Z"},
+	{Entity{"Palestine", "E4", "AS", 20, 39}, "E4"},
+	{Entity{"Lebanon", "OD", "AS", 20, 39}, "OD"},
+	{Entity{"Syria", "YK", "AS", 20, 39}, "6C YK"},
+	{Entity{"Jordan", "JY", "AS", 20, 39}, "JY"},
+	{Entity{"Iraq", "YI", "AS", 21, 39}, "HN YI"},
+	{Entity{"Iran", "EP", "AS", 21, 40}, "9B 9C 9D EP EQ"},
+	{Entity{"Saudi Arabia", "HZ", "AS", 21, 39}, "7Z 8Z HZ"},
+	{Entity{"Kuwait", "9K", "AS", 21, 39}, "9K"},
+	{Entity{"Bahrain", "A9", "AS", 21, 39}, "A9"},
+	{Entity{"Qatar", "A7", "AS", 21, 39}, "A7"},
+	{Entity{"United Arab Emirates", "A6", "AS", 21, 39}, "A6"},
+	{Entity{"Oman", "A4", "AS", 21, 39}, "A4"},
+	{Entity{"Yemen", "7O", "AS", 21, 39}, "7O"},
+	{Entity{"Kazakhstan", "UN", "AS", 17, 29}, "UN UO UP UQ"},
+	{Entity{"Uzbekistan", "UK", "AS", 17, 30}, "UJ UK UL UM"},
+	{Entity{"Turkmenistan", "EZ", "AS", 17, 30}, "EZ"},
+	{Entity{"Tajikistan", "EY", "AS", 17, 30}, "EY"},
+	{Entity{"Kyrgyzstan", "EX", "AS", 17, 30}, "EX"},
+	{Entity{"Afghanistan", "YA", "AS", 21, 40}, "T6 YA"},
+	{Entity{"Pakistan", "AP", "AS", 21, 41}, "6P 6Q 6R 6S AP AQ AR AS"},
+	{Entity{"India", "VU", "AS", 22, 41}, "8T 8U 8V 8W 8X 8Y AT AU AV AW VT VU VV VW"},
+	{Entity{"Andaman & Nicobar Islands", "VU4", "AS", 26, 49}, "VU4"},
+	{Entity{"Lakshadweep Islands", "VU7", "AS", 22, 41}, "VU7"},
+	{Entity{"Sri Lanka", "4S", "AS", 22, 41}, "4P 4Q 4R 4S"},
+	{Entity{"Maldives", "8Q", "AS", 22, 41}, "8Q"},
+	{Entity{"Nepal", "9N", "AS", 22, 42}, "9N"},
+	{Entity{"Bhutan", "A5", "AS", 22, 41}, "A5"},
+	{Entity{"Bangladesh", "S2", "AS", 22, 41}, "S2 S3"},
+	{Entity{"Myanmar", "XZ", "AS", 26, 49}, "XY XZ"},
+	{Entity{"Thailand", "HS", "AS", 26, 49}, "E2 HS"},
+	{Entity{"Laos", "XW", "AS", 26, 49}, "XW"},
+	{Entity{"Cambodia", "XU", "AS", 26, 49}, "XU"},
+	{Entity{"Vietnam", "3W", "AS", 26, 49}, "3W XV"},
+	{Entity{"China", "BY", "AS", 24, 44}, "3H 3I 3J 3K 3L 3M 3N 3O 3P 3Q 3R 3S 3T 3U B XS"},
+	{Entity{"Taiwan", "BV", "AS", 24, 44}, "BM BN BO BP BQ BU BV BW BX"},
+	{Entity{"Hong Kong", "VR", "AS", 24, 44}, "VR"},
+	{Entity{"Macao", "XX9", "AS", 24, 44}, "XX9"},
+	{Entity{"Mongolia", "JT", "AS", 23, 32}, "JT JU JV"},
+	{Entity{"Republic of Korea", "HL", "AS", 25, 44}, "6K 6L 6M 6N D7 D8 D9 DS DT HL"},
+	{Entity{"DPR of Korea", "P5", "AS", 25, 44}, "P5"},
+	{Entity{"Japan", "JA", "AS", 25, 45}, "7J 7K 7L 7M 7N 8J 8K 8L 8M 8N JA JE JF JG JH JI JJ JK JL JM JN JO JP JQ JR JS"},
+	{Entity{"Philippines", "DU", "OC", 27, 50}, "4D 4E 4F 4G 4H 4I DU DV DW DX DY DZ"},
+	{Entity{"West Malaysia", "9M2", "AS", 28, 54}, "9M 9W"},
+	{Entity{"Singapore", "9V", "AS", 28, 54}, "9V S6"},
+
+	// Oceania
+	{Entity{"East Malaysia", "9M6", "OC", 28, 54}, "9M6 9M8 9W6 9W8"},
+	{Entity{"Brunei Darussalam", "V8", "OC", 28, 54}, "V8"},
+	{Entity{"Indonesia", "YB", "OC", 28, 51}, "7A 7B 7C 7D 7E 7F 7G 7H 7I 8A 8B 8C 8D 8E 8F 8G 8H 8I PK PL PM PN PO YB YC YD YE YF YG YH"},
+	{Entity{"Timor-Leste", "4W", "OC", 28, 54}, "4W"},
+	{Entity{"Papua New Guinea", "P2", "OC", 28, 51}, "P2"},
+	{Entity{"Australia", "VK", "OC", 30, 59}, "AX VH VI VJ VK VL VM VN VZ"},
+	{Entity{"New Zealand", "ZL", "OC", 32, 60}, "ZK ZL ZM"},
+	{Entity{"Hawaii", "KH6", "OC", 31, 61}, "AH6 AH7 KH6 KH7 NH6 NH7 WH6 WH7"},
+	{Entity{"Guam", "KH2", "OC", 27, 64}, "AH2 KH2 NH2 WH2"},
+	{Entity{"Mariana Islands", "KH0", "OC", 27, 64}, "AH0 KH0 NH0 WH0"},
+	{Entity{"American Samoa", "KH8", "OC", 32, 62}, "AH8 KH8 NH8 WH8"},
+	{Entity{"Palau", "T8", "OC", 27, 64}, "T8"},
+	{Entity{"Micronesia", "V6", "OC", 27, 65}, "V6"},
+	{Entity{"Marshall Islands", "V7", "OC", 31, 65}, "V7"},
+	{Entity{"Nauru", "C2", "OC", 31, 65}, "C2"},
+	{Entity{"Western Kiribati", "T30", "OC", 31, 65}, "T30"},
+	{Entity{"Tuvalu", "T2", "OC", 31, 65}, "T2"},
+	{Entity{"Solomon Islands", "H44", "OC", 28, 51}, "H4"},
+	{Entity{"Vanuatu", "YJ", "OC", 32, 56}, "YJ"},
+	{Entity{"New Caledonia", "FK", "OC", 32, 56}, "FK"},
+	{Entity{"Fiji", "3D2", "OC", 32, 56}, "3D2"},
+	{Entity{"Samoa", "5W", "OC", 32, 62}, "5W"},
+	{Entity{"Tonga", "A3", "OC", 32, 62}, "A3"},
+	{Entity{"Wallis & Futuna Islands", "FW", "OC", 32, 62}, "FW"},
+	{Entity{"South Cook Islands", "E5", "OC", 32, 62}, "E5"},
+	{Entity{"French Polynesia", "FO", "OC", 32, 63}, "FO"},
+
+	// Africa
+	{Entity{"Canary Islands", "EA8", "AF", 33, 36}, "AM8 AN8 AO8 EA8 EB8 EC8 ED8 EE8 EF8 EG8 EH8"},
+	{Entity{"Ceuta & Melilla", "EA9", "AF", 33, 37}, "AM9 AN9 AO9 EA9 EB9 EC9 ED9 EE9 EF9 EG9 EH9"},
+	{Entity{"Madeira Islands", "CT3", "AF", 33, 36}, "CQ3 CQ9 CR3 CR9 CS3 CS9 CT3 CT9"},
+	{Entity{"Morocco", "CN", "AF", 33, 37}, "5C 5D 5E 5F 5G CN"},
+	{Entity{"Western Sahara", "S0", "AF", 33, 46}, "S0"},
+	{Entity{"Algeria", "7X", "AF", 33, 37}, "7R 7T 7U 7V 7W 7X 7Y"},
+	{Entity{"Tunisia", "3V", "AF", 33, 37}, "3V TS"},
+	{Entity{"Libya", "5A", "AF", 34, 38}, "5A"},
+	{Entity{"Egypt", "SU", "AF", 34, 38}, "6A 6B SS SU"},
+	{Entity{"Sudan", "ST", "AF", 34, 47}, "6T 6U ST"},
+	{Entity{"South Sudan", "Z8", "AF", 34, 48}, "Z8"},
+	{Entity{"Ethiopia", "ET", "AF", 37, 48}, "9E 9F ET"},
+	{Entity{"Eritrea", "E3", "AF", 37, 48}, "E3"},
+	{Entity{"Djibouti", "J2", "AF", 37, 48}, "J2"},
+	{Entity{"Somalia", "T5", "AF", 37, 48}, "6O T5"},
+	{Entity{"Kenya", "5Z", "AF", 37, 48}, "5Y 5Z"},
+	{Entity{"Uganda", "5X", "AF", 37, 48}, "5X"},
+	{Entity{"Tanzania", "5H", "AF", 37, 53}, "5H 5I"},
+	{Entity{"Rwanda", "9X", "AF", 36, 52}, "9X"},
+	{Entity{"Burundi", "9U", "AF", 36, 52}, "9U"},
+	{Entity{"Dem. Rep. of the Congo", "9Q", "AF", 36, 52}, "9O 9P 9Q 9R 9S 9T"},
+	{Entity{"Republic of the Congo", "TN", "AF", 36, 52}, "TN"},
+	{Entity{"Gabon", "TR", "AF", 36, 52}, "TR"},
+	{Entity{"Cameroon", "TJ", "AF", 36, 47}, "TJ"},
+	{Entity{"Central African Republic", "TL", "AF", 36, 47}, "TL"},
+	{Entity{"Chad", "TT", "AF", 36, 47}, "TT"},
+	{Entity{"Equatorial Guinea", "3C", "AF", 36, 47}, "3C"},
+	{Entity{"Sao Tome & Principe", "S9", "AF", 36, 47}, "S9"},
+	{Entity{"Niger", "5U", "AF", 35, 46}, "5U"},
+	{Entity{"Nigeria", "5N", "AF", 35, 46}, "5N 5O"},
+	{Entity{"Benin", "TY", "AF", 35, 46}, "TY"},
+	{Entity{"Togo", "5V", "AF", 35, 46}, "5V"},
+	{Entity{"Ghana", "9G", "AF", 35, 46}, "9G"},
+	{Entity{"Cote d'Ivoire", "TU", "AF", 35, 46}, "TU"},
+	{Entity{"Burkina Faso", "XT", "AF", 35, 46}, "XT"},
+	{Entity{"Mali", "TZ", "AF", 35, 46}, "TZ"},
+	{Entity{"Mauritania", "5T", "AF", 35, 46}, "5T"},
+	{Entity{"Senegal", "6W", "AF", 35, 46}, "6V 6W"},
+	{Entity{"The Gambia", "C5", "AF", 35, 46}, "C5"},
+	{Entity{"Guinea-Bissau", "J5", "AF", 35, 46}, "J5"},
+	{Entity{"Guinea", "3X", "AF", 35, 46}, "3X"},
+	{Entity{"Sierra Leone", "9L", "AF", 35, 46}, "9L"},
+	{Entity{"Liberia", "EL", "AF", 35, 46}, "5L 5M 6Z A8 D5 EL"},
+	{Entity{"Cape Verde", "D4", "AF", 35, 46}, "D4"},
+	{Entity{"Angola", "D2", "AF", 36, 52}, "D2 D3"},
+	{Entity{"Zambia", "9J", "AF", 36, 53}, "9I 9J"},
+	{Entity{"Malawi", "7Q", "AF", 37, 53}, "7Q"},
+	{Entity{"Mozambique", "C9", "AF", 37, 53}, "C8 C9"},
+	{Entity{"Zimbabwe", "Z2", "AF", 38, 53}, "Z2"},
+	{Entity{"Botswana", "A2", "AF", 38, 57}, "8O A2"},
+	{Entity{"Namibia", "V5", "AF", 38, 57}, "V5"},
+	{Entity{"South Africa", "ZS", "AF", 38, 57}, "ZR ZS ZT ZU"},
+	{Entity{"Lesotho", "7P", "AF", 38, 57}, "7P"},
+	{Entity{"Kingdom of Eswatini", "3DA", "AF", 38, 57}, "3DA"},
+	{Entity{"Madagascar", "5R", "AF", 39, 53}, "5R 5S 6X"},
+	{Entity{"Mauritius", "3B8", "AF", 39, 53}, "3B8"},
+	{Entity{"Reunion Island", "FR", "AF", 39, 53}, "FR"},
+	{Entity{"Mayotte", "FH", "AF", 39, 53}, "FH"},
+	{Entity{"Comoros", "D6", "AF", 39, 53}, "D6"},
+	{Entity{"Seychelles", "S7", "AF", 39, 53}, "S7"},
+	{Entity{"St. Helena", "ZD7", "AF", 36, 66}, "ZD7"},
+	{Entity{"Ascension Island", "ZD8", "AF", 36, 66}, "ZD8"},
+}
@@ -0,0 +1,108 @@
+package cabrillo
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntityDB(t *testing.T) {
+	t.Run("Lookup", func(t *testing.T) {
+		db := NewEntityDB()
+
+		tests := []struct {
+			call     string
+			expected string
+		}{
+			{"AA1ZZZ", "United States"},
+			{"k9qzo", "United States"},
+			{"KH6ABC", "Hawaii"},
+			{"KH6/W1AW", "Hawaii"},
+			{"W1AW/KH6", "Hawaii"},
+			{"W1AW/P", "United States"},
+			{"W1AW/4", "United States"},
+			{"P29AS", "Papua New Guinea"},
+			{"4S7TWG", "Sri Lanka"},
+			{"JT1FAX", "Mongolia"},
+			{"ED8X", "Canary Islands"},
+			{"EA6ZS", "Balearic Islands"},
+			{"GM3ABC", "Scotland"},
+			{"M6W", "England"},
+			{"RA3Y", "European Russia"},
+			{"UA9ABC", "Asiatic Russia"},
+			{"UA1ABC/9", "Asiatic Russia"},
+			{"RA2FA", "Kaliningrad"},
+			{"F/G3ABC", "France"},
+			{"VP2E/W1AW", "Anguilla"},
+			{"W1AW/VP2E", "Anguilla"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.call, func(t *testing.T) {
+				e, err := db.Lookup(tt.call)
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, e.Name)
+			})
+		}
+
+		t.Run("known callsign of the same length", func(t *testing.T) {
+			db := NewEntityDB()
+			db.Add(Entity{Name: "Alaska"}, "=KH6A")
+
+			for _, call := range []string{"KH6A/W1AW", "W1AW/KH6A"} {
+				e, err := db.Lookup(call)
+				require.NoError(t, err)
+				require.Equal(t, "United States", e.Name, call)
+			}
+		})
+
+		t.Run("maritime mobile", func(t *testing.T) {
+			_, err := db.Lookup("W1AW/MM")
+			require.True(t, errors.Is(err, ErrUnknownEntity))
+		})
+
+		t.Run("unknown", func(t *testing.T) {
+			_, err := db.Lookup("Q1ABC")
+			require.True(t, errors.Is(err, ErrUnknownEntity))
+		})
+	})
+
+	t.Run("ParseCtyDat", func(t *testing.T) {
+		fh, err := os.Open("testdata/cty.dat")
+		require.NoError(t, err)
+		defer fh.Close()
+
+		db, err := ParseCtyDat(fh)
+		require.NoError(t, err)
+
+		e, err := db.Lookup("W1AW")
+		require.NoError(t, err)
+		require.Equal(t, Entity{"United States", "K", "NA", 5, 8}, e)
+
+		e, err = db.Lookup("KL7HF")
+		require.NoError(t, err)
+		require.Equal(t, "United States", e.Name)
+		require.Equal(t, 3, e.CQZone)
+		require.Equal(t, 6, e.ITUZone)
+
+		e, err = db.Lookup("TA1ABC")
+		require.NoError(t, err)
+		require.Equal(t, "European Turkey", e.Name)
+		require.Equal(t, "TA1", e.Prefix)
+		require.Equal(t, "EU", e.Continent)
+
+		e, err = db.Lookup("TA2ABC")
+		require.NoError(t, err)
+		require.Equal(t, "Asiatic Turkey", e.Name)
+
+		e, err = db.Lookup("KG4AA")
+		require.NoError(t, err)
+		require.Equal(t, "Guantanamo Bay", e.Name)
+
+		_, err = ParseCtyDat(strings.NewReader("Broken: 05: 08: NA;"))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,141 @@
+package cabrillo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The rules checked by SentExchangeCheck and ReceivedExchangeCheck.
+const (
+	RuleSentCallsign     = "SENT-CALLSIGN"
+	RuleSentExchange     = "SENT-EXCHANGE"
+	RuleReceivedExchange = "RECEIVED-EXCHANGE"
+)
+
+// SentExchangeCheck returns a Check that reports QSOs where the sent callsign
+// or the sent exchange differs from the value sent in most QSOs of the log. The
+// fields are the indexes, starting at 0, of the exchange fields that are fixed
+// in the contest, e.g. the CQ zone. Without fields the whole exchange is
+// expected to be fixed. Use no fields for contests with serial numbers.
+func SentExchangeCheck(fields ...int) Check {
+	return func(l Log) []Issue {
+		calls := make([]string, len(l.QSOs))
+		exchanges := make([]string, len(l.QSOs))
+		for i, q := range l.QSOs {
+			calls[i] = normalizeCall(q.TxInfo.Callsign)
+			exchanges[i] = exchangeFields(q.TxInfo.Exchange, fields)
+		}
+
+		var issues []Issue
+		call, callCount := majority(calls)
+		exchange, exchangeCount := majority(exchanges)
+		for i := range l.QSOs {
+			if calls[i] != call {
+				issues = append(issues, Issue{
+					QSO:      i,
+					Severity: SeverityWarning,
+					Rule:     RuleSentCallsign,
+					Message:  fmt.Sprintf("sent callsign %s differs from %s sent in %d of %d QSOs", calls[i], call, callCount, len(calls)),
+				})
+			}
+			if exchanges[i] != exchange {
+				issues = append(issues, Issue{
+					QSO:      i,
+					Severity: SeverityWarning,
+					Rule:     RuleSentExchange,
+					Message:  fmt.Sprintf("sent exchange %q differs from %q sent in %d of %d QSOs", exchanges[i], exchange, exchangeCount, len(exchanges)),
+				})
+			}
+		}
+		return issues
+	}
+}
+
+// ReceivedExchangeCheck returns a Check that reports QSOs where a station
+// worked several times sent a fixed exchange different from the one it sent in
+// most of the other QSOs, e.g. a CQ zone or state that changed between bands.
+// The fields are the indexes, starting at 0, of the fixed exchange fields.
+// Without fields the whole exchange is expected to be fixed.
+func ReceivedExchangeCheck(fields ...int) Check {
+	return func(l Log) []Issue {
+		byCall := make(map[string][]int)
+		var order []string
+		for i, q := range l.QSOs {
+			call := normalizeCall(q.RxInfo.Callsign)
+			if _, ok := byCall[call]; !ok {
+				order = append(order, call)
+			}
+			byCall[call] = append(byCall[call], i)
+		}
+
+		var issues []Issue
+		for _, call := range order {
+			indexes := byCall[call]
+			if len(indexes) < 2 {
+				continue
+			}
+
+			exchanges := make([]string, len(indexes))
+			for j, i := range indexes {
+				exchanges[j] = exchangeFields(l.QSOs[i].RxInfo.Exchange, fields)
+			}
+
+			exchange, count := majority(exchanges)
+			for j, i := range indexes {
+				if exchanges[j] == exchange {
+					continue
+				}
+				issues = append(issues, Issue{
+					QSO:      i,
+					Severity: SeverityWarning,
+					Rule:     RuleReceivedExchange,
+					Message:  fmt.Sprintf("copied %q from %s, but %q in %d other QSOs", exchanges[j], l.QSOs[i].RxInfo.Callsign, exchange, count),
+				})
+			}
+		}
+		return issues
+	}
+}
+
+// exchangeFields returns the exchange fields at the indexes, separated by a
+// space. Without indexes it returns the whole exchange with normalized spacing.
+// Numeric fields are normalized like in exchangesEqual, so "05" becomes "5".
+func exchangeFields(exchange string, indexes []int) string {
+	fields := strings.Fields(strings.ToUpper(exchange))
+	for i, f := range fields {
+		if n, err := strconv.Atoi(f); err == nil {
+			fields[i] = strconv.Itoa(n)
+		}
+	}
+	if len(indexes) == 0 {
+		return strings.Join(fields, " ")
+	}
+
+	selected := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		if i < len(fields) {
+			selected = append(selected, fields[i])
+		}
+	}
+	return strings.Join(selected, " ")
+}
+
+// majority returns the most common value and the number of times it occurs. On
+// a tie, the value occurring first wins.
+func majority(values []string) (string, int) {
+	counts := make(map[string]int)
+	var (
+		best      string
+		bestCount int
+	)
+	for _, v := range values {
+		counts[v]++
+	}
+	for _, v := range values {
+		if counts[v] > bestCount {
+			best, bestCount = v, counts[v]
+		}
+	}
+	return best, bestCount
+}
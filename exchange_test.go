@@ -0,0 +1,56 @@
+package cabrillo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSentExchangeCheck(t *testing.T) {
+	l := Log{
+		QSOs: mustQSOs(t,
+			"QSO: 7030 CW 2017-11-25 2121 K1IR 599 5 SQ9E 599 15",
+			"QSO: 7030 CW 2017-11-25 2122 K1IR 599 5 EA2TT 599 14",
+			"QSO: 7030 CW 2017-11-25 2123 K1IR 599 4 HA9A 599 15",
+			"QSO: 7030 CW 2017-11-25 2124 K1IT 599 5 HA3LN 599 15",
+			"QSO: 7030 CW 2017-11-25 2125 K1IR 599 05 SP6CJK 599 15",
+		),
+	}
+
+	require.Equal(t, []Issue{
+		// The padded zone 05 is the same as 5.
+		{QSO: 2, Severity: SeverityWarning, Rule: RuleSentExchange, Message: `sent exchange "4" differs from "5" sent in 4 of 5 QSOs`},
+		{QSO: 3, Severity: SeverityWarning, Rule: RuleSentCallsign, Message: "sent callsign K1IT differs from K1IR sent in 4 of 5 QSOs"},
+	}, Validate(l, SentExchangeCheck()))
+
+	t.Run("fields", func(t *testing.T) {
+		ss := Log{
+			QSOs: []QSO{
+				{TxInfo: Info{Callsign: "N8BJQ", Exchange: "1 A 72 OH"}},
+				{TxInfo: Info{Callsign: "N8BJQ", Exchange: "2 A 72 OH"}},
+				{TxInfo: Info{Callsign: "N8BJQ", Exchange: "3 A 27 OH"}},
+			},
+		}
+		require.Equal(t, []Issue{
+			{QSO: 2, Severity: SeverityWarning, Rule: RuleSentExchange, Message: `sent exchange "A 27 OH" differs from "A 72 OH" sent in 2 of 3 QSOs`},
+		}, Validate(ss, SentExchangeCheck(1, 2, 3)))
+	})
+}
+
+func TestReceivedExchangeCheck(t *testing.T) {
+	l := Log{
+		QSOs: mustQSOs(t,
+			"QSO: 7030 CW 2017-11-25 2121 K1IR 599 5 SQ9E 599 15",
+			"QSO: 14030 CW 2017-11-25 2122 K1IR 599 5 SQ9E 599 16",
+			"QSO: 21030 CW 2017-11-25 2123 K1IR 599 5 SQ9E 599 15",
+			"QSO: 7030 CW 2017-11-25 2124 K1IR 599 5 EA2TT 599 14",
+			"QSO: 14030 CW 2017-11-25 2125 K1IR 599 5 EA2TT 599 14",
+			"QSO: 7030 CW 2017-11-25 2126 K1IR 599 5 HA3LN 599 15",
+			"QSO: 14030 CW 2017-11-25 2127 K1IR 599 5 HA3LN 599 015",
+		),
+	}
+
+	require.Equal(t, []Issue{
+		{QSO: 1, Severity: SeverityWarning, Rule: RuleReceivedExchange, Message: `copied "16" from SQ9E, but "15" in 2 other QSOs`},
+	}, Validate(l, ReceivedExchangeCheck()))
+}
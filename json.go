@@ -0,0 +1,375 @@
+package cabrillo
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// The JSON representations of the types of the package. They are described by
+// the JSON Schema in cabrillo.schema.json and only change in backwards
+// compatible ways. Timestamps are RFC 3339 in UTC and signal reports are
+// strings like "599".
+
+type jsonLog struct {
+	Address          jsonAddress       `json:"address"`
+	Callsign         string            `json:"callsign"`
+	Categories       []Category        `json:"categories"`
+	Certificate      bool              `json:"certificate"`
+	ClaimedScore     int               `json:"claimed_score"`
+	Club             string            `json:"club"`
+	Contest          string            `json:"contest"`
+	CreatedBy        string            `json:"created_by"`
+	Email            string            `json:"email"`
+	ExtensibleFields []ExtensibleField `json:"extensible_fields"`
+	GridLocator      string            `json:"grid_locator"`
+	Location         string            `json:"location"`
+	Name             string            `json:"name"`
+	OffTimes         []OffTime         `json:"offtimes"`
+	Operators        []string          `json:"operators"`
+	QSOs             []QSO             `json:"qsos"`
+	SoapBox          []string          `json:"soapbox"`
+	Version          string            `json:"version"`
+	XQSOs            []QSO             `json:"x_qsos"`
+}
+
+type jsonAddress struct {
+	Lines         []string `json:"lines"`
+	City          string   `json:"city"`
+	StateProvince string   `json:"state_province"`
+	PostalCode    string   `json:"postal_code"`
+	Country       string   `json:"country"`
+}
+
+type jsonQSO struct {
+	Frequency   string `json:"frequency"`
+	Mode        string `json:"mode"`
+	Timestamp   string `json:"timestamp"`
+	Sent        Info   `json:"sent"`
+	Received    Info   `json:"received"`
+	Transmitter int    `json:"transmitter"`
+}
+
+type jsonInfo struct {
+	Callsign     string `json:"callsign"`
+	SignalReport *RST   `json:"rst,omitempty"`
+	Exchange     string `json:"exchange"`
+}
+
+type jsonOffTime struct {
+	Begin string `json:"begin"`
+	End   string `json:"end"`
+}
+
+type jsonCategory struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type jsonExtensibleField struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (l Log) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonLog{
+		Address: jsonAddress{
+			Lines:         nonNilStrings(l.Address.Address),
+			City:          l.Address.City,
+			StateProvince: l.Address.StateProvince,
+			PostalCode:    l.Address.PostalCode,
+			Country:       l.Address.Country,
+		},
+		Callsign:         l.CallSign,
+		Categories:       nonNilCategories(l.Categories),
+		Certificate:      l.Certificate,
+		ClaimedScore:     l.ClaimedScore,
+		Club:             l.Club,
+		Contest:          l.Contest,
+		CreatedBy:        l.CreatedBy,
+		Email:            l.Email,
+		ExtensibleFields: nonNilExtensibleFields(l.ExtensibleFields),
+		GridLocator:      l.GridLocator,
+		Location:         l.Location,
+		Name:             l.Name,
+		OffTimes:         nonNilOffTimes(l.OffTimes),
+		Operators:        nonNilStrings(l.Operators),
+		QSOs:             nonNilQSOs(l.QSOs),
+		SoapBox:          nonNilStrings(l.SoapBox),
+		Version:          l.Version,
+		XQSOs:            nonNilQSOs(l.XQSOs),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Like in ParseLog, CERTIFICATE
+// defaults to true when it's absent.
+func (l *Log) UnmarshalJSON(data []byte) error {
+	v := jsonLog{Certificate: true}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*l = Log{
+		Address: Address{
+			Address:       v.Address.Lines,
+			City:          v.Address.City,
+			StateProvince: v.Address.StateProvince,
+			PostalCode:    v.Address.PostalCode,
+			Country:       v.Address.Country,
+		},
+		CallSign:         v.Callsign,
+		Categories:       v.Categories,
+		Certificate:      v.Certificate,
+		ClaimedScore:     v.ClaimedScore,
+		Club:             v.Club,
+		Contest:          v.Contest,
+		CreatedBy:        v.CreatedBy,
+		Email:            v.Email,
+		ExtensibleFields: v.ExtensibleFields,
+		GridLocator:      v.GridLocator,
+		Location:         v.Location,
+		Name:             v.Name,
+		OffTimes:         v.OffTimes,
+		Operators:        v.Operators,
+		QSOs:             v.QSOs,
+		SoapBox:          v.SoapBox,
+		Version:          v.Version,
+		XQSOs:            v.XQSOs,
+	}
+
+	// Empty lists decode to nil, like in logs returned by ParseLog.
+	if len(l.Address.Address) == 0 {
+		l.Address.Address = nil
+	}
+	if len(l.Categories) == 0 {
+		l.Categories = nil
+	}
+	if len(l.ExtensibleFields) == 0 {
+		l.ExtensibleFields = nil
+	}
+	if len(l.OffTimes) == 0 {
+		l.OffTimes = nil
+	}
+	if len(l.Operators) == 0 {
+		l.Operators = nil
+	}
+	if len(l.QSOs) == 0 {
+		l.QSOs = nil
+	}
+	if len(l.SoapBox) == 0 {
+		l.SoapBox = nil
+	}
+	if len(l.XQSOs) == 0 {
+		l.XQSOs = nil
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (q QSO) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonQSO{
+		Frequency:   q.Frequency,
+		Mode:        q.Mode,
+		Timestamp:   formatJSONTime(q.Timestamp),
+		Sent:        q.TxInfo,
+		Received:    q.RxInfo,
+		Transmitter: q.Transmitter,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (q *QSO) UnmarshalJSON(data []byte) error {
+	var v jsonQSO
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	ts, err := parseJSONTime(v.Timestamp)
+	if err != nil {
+		return fmt.Errorf("parsing QSO timestamp: %w", err)
+	}
+
+	*q = QSO{
+		Frequency:   v.Frequency,
+		Mode:        v.Mode,
+		Timestamp:   ts,
+		TxInfo:      v.Sent,
+		RxInfo:      v.Received,
+		Transmitter: v.Transmitter,
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The signal report is left out when
+// there is none.
+func (i Info) MarshalJSON() ([]byte, error) {
+	v := jsonInfo{
+		Callsign: i.Callsign,
+		Exchange: i.Exchange,
+	}
+	if i.SignalReport != (RST{}) {
+		v.SignalReport = &i.SignalReport
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *Info) UnmarshalJSON(data []byte) error {
+	var v jsonInfo
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*i = Info{
+		Callsign: v.Callsign,
+		Exchange: v.Exchange,
+	}
+	if v.SignalReport != nil {
+		i.SignalReport = *v.SignalReport
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The report is a string like "599".
+func (r RST) MarshalJSON() ([]byte, error) {
+	if r == (RST{}) {
+		return json.Marshal("")
+	}
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *RST) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*r = RST{}
+		return nil
+	}
+
+	rst, err := NewRST(s)
+	if err != nil {
+		return fmt.Errorf("parsing signal report %q: %w", s, err)
+	}
+	*r = rst
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (ot OffTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonOffTime{
+		Begin: formatJSONTime(ot.Begin),
+		End:   formatJSONTime(ot.End),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (ot *OffTime) UnmarshalJSON(data []byte) error {
+	var v jsonOffTime
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	begin, err := parseJSONTime(v.Begin)
+	if err != nil {
+		return fmt.Errorf("parsing off-time begin: %w", err)
+	}
+	end, err := parseJSONTime(v.End)
+	if err != nil {
+		return fmt.Errorf("parsing off-time end: %w", err)
+	}
+
+	*ot = OffTime{Begin: begin, End: end}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c Category) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonCategory(c))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Category) UnmarshalJSON(data []byte) error {
+	var v jsonCategory
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*c = Category(v)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (f ExtensibleField) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonExtensibleField{
+		Name:   f.Name,
+		Values: nonNilStrings(f.Values),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *ExtensibleField) UnmarshalJSON(data []byte) error {
+	var v jsonExtensibleField
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*f = ExtensibleField(v)
+	if len(f.Values) == 0 {
+		f.Values = nil
+	}
+	return nil
+}
+
+// formatJSONTime formats a timestamp as RFC 3339 in UTC.
+func formatJSONTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// parseJSONTime parses an RFC 3339 timestamp and converts it to UTC.
+func parseJSONTime(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
+
+// The nonNil functions make sure lists are encoded as empty arrays rather than
+// null, as the schema requires.
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func nonNilCategories(s []Category) []Category {
+	if s == nil {
+		return []Category{}
+	}
+	return s
+}
+
+func nonNilExtensibleFields(s []ExtensibleField) []ExtensibleField {
+	if s == nil {
+		return []ExtensibleField{}
+	}
+	return s
+}
+
+func nonNilOffTimes(s []OffTime) []OffTime {
+	if s == nil {
+		return []OffTime{}
+	}
+	return s
+}
+
+func nonNilQSOs(s []QSO) []QSO {
+	if s == nil {
+		return []QSO{}
+	}
+	return s
+}
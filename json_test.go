@@ -0,0 +1,148 @@
+package cabrillo
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQSOJSON(t *testing.T) {
+	q, err := NewQSO("QSO: 14025 CW 2023-05-27 0001 N8BJQ 599 1 DL1ABC 57 12 1", 1)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(q)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"frequency": "14025",
+		"mode": "CW",
+		"timestamp": "2023-05-27T00:01:00Z",
+		"sent": {"callsign": "N8BJQ", "rst": "599", "exchange": "1"},
+		"received": {"callsign": "DL1ABC", "rst": "57", "exchange": "12"},
+		"transmitter": 1
+	}`, string(data))
+
+	var decoded QSO
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, q, decoded)
+
+	t.Run("without signal report", func(t *testing.T) {
+		q := QSO{RxInfo: Info{Callsign: "W1AW", Exchange: "1 A 72 CT"}}
+		data, err := json.Marshal(q.RxInfo)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"callsign": "W1AW", "exchange": "1 A 72 CT"}`, string(data))
+	})
+
+	t.Run("time zone", func(t *testing.T) {
+		var decoded QSO
+		require.NoError(t, json.Unmarshal([]byte(`{"timestamp": "2023-05-27T02:01:00+02:00"}`), &decoded))
+		require.Equal(t, time.Date(2023, time.May, 27, 0, 1, 0, 0, time.UTC), decoded.Timestamp)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var decoded QSO
+		require.Error(t, json.Unmarshal([]byte(`{"timestamp": "2023-05-27 0001"}`), &decoded))
+		err := json.Unmarshal([]byte(`{"timestamp": "2023-05-27T00:01:00Z", "sent": {"rst": "5999"}}`), &decoded)
+		require.EqualError(t, err, `parsing signal report "5999": invalid RST report length`)
+	})
+}
+
+func TestLogJSON(t *testing.T) {
+	fh, err := os.Open("testdata/allfields.log")
+	require.NoError(t, err)
+	defer fh.Close()
+
+	l, err := ParseLog(fh)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(l)
+	require.NoError(t, err)
+
+	var decoded Log
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, l, decoded)
+
+	t.Run("empty log", func(t *testing.T) {
+		data, err := json.Marshal(Log{})
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"qsos":[]`)
+
+		var decoded Log
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		require.Equal(t, Log{}, decoded)
+	})
+
+	t.Run("certificate defaults to yes", func(t *testing.T) {
+		var decoded Log
+		require.NoError(t, json.Unmarshal([]byte(`{"callsign":"N8BJQ"}`), &decoded))
+		require.Equal(t, Log{CallSign: "N8BJQ", Certificate: true}, decoded)
+	})
+
+	t.Run("schema", func(t *testing.T) {
+		schemaData, err := os.ReadFile("cabrillo.schema.json")
+		require.NoError(t, err)
+		var schema map[string]interface{}
+		require.NoError(t, json.Unmarshal(schemaData, &schema))
+
+		for _, l := range []Log{l, {}} {
+			data, err := json.Marshal(l)
+			require.NoError(t, err)
+			var doc interface{}
+			require.NoError(t, json.Unmarshal(data, &doc))
+			validateSchema(t, schema, schema, doc, "$")
+		}
+	})
+}
+
+// validateSchema validates doc against the subset of JSON Schema used by
+// cabrillo.schema.json.
+func validateSchema(t *testing.T, root, schema map[string]interface{}, doc interface{}, path string) {
+	t.Helper()
+
+	if ref, ok := schema["$ref"].(string); ok {
+		def := root["$defs"].(map[string]interface{})[strings.TrimPrefix(ref, "#/$defs/")]
+		require.NotNil(t, def, "%s: unknown $ref %s", path, ref)
+		validateSchema(t, root, def.(map[string]interface{}), doc, path)
+		return
+	}
+
+	switch schema["type"] {
+	case "object":
+		obj, ok := doc.(map[string]interface{})
+		require.True(t, ok, "%s: expected an object", path)
+		props, _ := schema["properties"].(map[string]interface{})
+		for _, name := range schema["required"].([]interface{}) {
+			require.Contains(t, obj, name, "%s: missing required property", path)
+		}
+		for name, v := range obj {
+			prop, ok := props[name]
+			require.True(t, ok, "%s: unexpected property %s", path, name)
+			validateSchema(t, root, prop.(map[string]interface{}), v, path+"."+name)
+		}
+	case "array":
+		arr, ok := doc.([]interface{})
+		require.True(t, ok, "%s: expected an array", path)
+		for i, v := range arr {
+			validateSchema(t, root, schema["items"].(map[string]interface{}), v, path+"["+strconv.Itoa(i)+"]")
+		}
+	case "string":
+		s, ok := doc.(string)
+		require.True(t, ok, "%s: expected a string", path)
+		if pattern, ok := schema["pattern"].(string); ok {
+			require.Regexp(t, regexp.MustCompile(pattern), s, "%s", path)
+		}
+	case "integer":
+		n, ok := doc.(float64)
+		require.True(t, ok && n == float64(int(n)), "%s: expected an integer", path)
+	case "boolean":
+		_, ok := doc.(bool)
+		require.True(t, ok, "%s: expected a boolean", path)
+	default:
+		t.Fatalf("%s: unsupported schema type %v", path, schema["type"])
+	}
+}
@@ -0,0 +1,219 @@
+package cabrillo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// The actions taken on a QSO during log checking.
+const (
+	// ActionRemoved is a QSO removed from the log.
+	ActionRemoved = "REMOVED"
+	// ActionPenalized is a QSO removed from the log with an additional penalty.
+	ActionPenalized = "PENALIZED"
+)
+
+// LogCheckReport lists the QSOs removed or penalized while checking a log and
+// the effect on the score. It is what contest sponsors send to each entrant
+// after log checking.
+type LogCheckReport struct {
+	Callsign     string          `json:"callsign"`
+	Contest      string          `json:"contest"`
+	ClaimedScore int             `json:"claimed_score"`
+	Before       ReportScore     `json:"before"`
+	After        ReportScore     `json:"after"`
+	Entries      []LogCheckEntry `json:"entries"`
+}
+
+// ReportScore summarizes a score.
+type ReportScore struct {
+	QSOs        int `json:"qsos"`
+	Points      int `json:"points"`
+	Multipliers int `json:"multipliers"`
+	Total       int `json:"total"`
+}
+
+// LogCheckEntry is a QSO removed or penalized during log checking.
+type LogCheckEntry struct {
+	QSO    QSO         `json:"qso"`
+	Status CheckStatus `json:"status"`
+	Action string      `json:"action"`
+	Reason string      `json:"reason"`
+	// Counterpart is the copy of the QSO from the log of the station worked.
+	Counterpart *QSO `json:"counterpart,omitempty"`
+	// CorrectCall is the callsign of the station most likely worked when the
+	// call was busted.
+	CorrectCall string `json:"correct_call,omitempty"`
+	// Penalty is the number of QSO points deducted in addition to removing
+	// the QSO.
+	Penalty int `json:"penalty,omitempty"`
+}
+
+type reportOptions struct {
+	penalties map[CheckStatus]int
+}
+
+// ReportOption is used to customize a LogCheckReport.
+type ReportOption func(*reportOptions)
+
+// WithPenalty deducts the points of the QSO multiplied by factor in addition to
+// removing QSOs with the given status. For example, a factor of 1 for
+// CheckNotInLog makes a not in log QSO cost its own points on top of the
+// removal. By default there are no penalties.
+func WithPenalty(status CheckStatus, factor int) ReportOption {
+	return func(o *reportOptions) {
+		o.penalties[status] = factor
+	}
+}
+
+// removedStatuses are the cross-check outcomes that remove a QSO from the log.
+var removedStatuses = map[CheckStatus]struct{}{
+	CheckNotInLog:       {},
+	CheckBustedCall:     {},
+	CheckBustedExchange: {},
+	CheckTimeMismatch:   {},
+}
+
+// NewLogCheckReport builds the log checking report of the log from the outcome
+// of cross-checking its QSOs. QSOs that didn't match are removed from the log
+// before scoring it again with the scorer. Checks for QSOs that aren't part of
+// the log are ignored.
+func NewLogCheckReport(l Log, checks []QSOCheck, s Scorer, opts ...ReportOption) (LogCheckReport, error) {
+	opt := &reportOptions{
+		penalties: make(map[CheckStatus]int),
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	before, err := s.Score(l)
+	if err != nil {
+		return LogCheckReport{}, fmt.Errorf("scoring log before checking: %w", err)
+	}
+
+	r := LogCheckReport{
+		Callsign:     stationCallsign(l),
+		Contest:      l.Contest,
+		ClaimedScore: l.ClaimedScore,
+		Before:       newReportScore(before),
+	}
+
+	// Index the checks by QSO so they can be applied in the order of the log.
+	byQSO := make(map[QSO][]QSOCheck)
+	for _, c := range checks {
+		byQSO[c.QSO] = append(byQSO[c.QSO], c)
+	}
+
+	checked := l
+	checked.QSOs = nil
+	var penalty int
+	for i, q := range l.QSOs {
+		pending := byQSO[q]
+		if len(pending) == 0 {
+			checked.QSOs = append(checked.QSOs, q)
+			continue
+		}
+		c := pending[0]
+		byQSO[q] = pending[1:]
+
+		if _, ok := removedStatuses[c.Status]; !ok {
+			checked.QSOs = append(checked.QSOs, q)
+			continue
+		}
+
+		entry := LogCheckEntry{
+			QSO:         q,
+			Status:      c.Status,
+			Action:      ActionRemoved,
+			Reason:      c.Reason,
+			Counterpart: c.Counterpart,
+			CorrectCall: c.CorrectCall,
+		}
+		if factor := opt.penalties[c.Status]; factor > 0 {
+			entry.Action = ActionPenalized
+			entry.Penalty = before.QSOs[i].Points * factor
+			penalty += entry.Penalty
+		}
+		r.Entries = append(r.Entries, entry)
+	}
+
+	after, err := s.Score(checked)
+	if err != nil {
+		return LogCheckReport{}, fmt.Errorf("scoring log after checking: %w", err)
+	}
+	r.After = newReportScore(after)
+	r.After.Points -= penalty
+	r.After.Total = r.After.Points * r.After.Multipliers
+
+	return r, nil
+}
+
+func newReportScore(s Score) ReportScore {
+	return ReportScore{
+		QSOs:        s.Count(QSOValid),
+		Points:      s.Points,
+		Multipliers: s.Multipliers,
+		Total:       s.Total,
+	}
+}
+
+// WriteJSON writes the report as JSON.
+func (r LogCheckReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteText writes the report as plain text.
+func (r LogCheckReport) WriteText(w io.Writer) error {
+	ew := &errWriter{w: w}
+
+	ew.printf("Log checking report for %s\n", r.Callsign)
+	ew.printf("Contest: %s\n\n", r.Contest)
+
+	const row = "%-16s%6v%8v%7v%10v\n"
+	ew.printf(row, "", "QSOs", "Points", "Mults", "Score")
+	ew.printf(row, "Claimed", "", "", "", r.ClaimedScore)
+	ew.printf(row, "Before checking", r.Before.QSOs, r.Before.Points, r.Before.Multipliers, r.Before.Total)
+	ew.printf(row, "After checking", r.After.QSOs, r.After.Points, r.After.Multipliers, r.After.Total)
+
+	if len(r.Entries) == 0 {
+		ew.printf("\nNo QSOs were removed.\n")
+		return ew.err
+	}
+
+	ew.printf("\n%d QSOs were removed:\n", len(r.Entries))
+	for _, e := range r.Entries {
+		ew.printf("\n%s\n", e.QSO)
+		ew.printf("  %s %s: %s\n", e.Action, e.Status, e.Reason)
+		if e.Penalty > 0 {
+			ew.printf("  penalty: %d points\n", e.Penalty)
+		}
+		if e.Counterpart != nil {
+			ew.printf("  %s logged: %s\n", e.Counterpart.TxInfo.Callsign, e.Counterpart)
+		}
+	}
+
+	return ew.err
+}
+
+// errWriter wraps a writer, remembering the first error encountered so it
+// only needs to be checked once.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) Write(p []byte) (int, error) {
+	if ew.err != nil {
+		return 0, ew.err
+	}
+	var n int
+	n, ew.err = ew.w.Write(p)
+	return n, ew.err
+}
+
+func (ew *errWriter) printf(format string, args ...interface{}) {
+	fmt.Fprintf(ew, format, args...)
+}
@@ -0,0 +1,81 @@
+package cabrillo
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogCheckReport(t *testing.T) {
+	k1ir := Log{
+		CallSign:     "K1IR",
+		Contest:      "CQ-WW-CW",
+		ClaimedScore: 24,
+		QSOs: mustQSOs(t,
+			"QSO: 7030 CW 2017-11-25 2121 K1IR 599 5 SQ9E 599 15",
+			"QSO: 7030 CW 2017-11-25 2122 K1IR 599 5 EA2TT 599 14",
+			"QSO: 14030 CW 2017-11-25 2200 K1IR 599 5 HA3LN 599 15",
+			"QSO: 14030 CW 2017-11-25 2201 K1IR 599 5 JA1ABC 599 25",
+		),
+	}
+	logs := []Log{
+		k1ir,
+		{CallSign: "SQ9E", QSOs: mustQSOs(t, "QSO: 7030 CW 2017-11-25 2122 SQ9E 599 15 K1IR 599 5")},
+		{CallSign: "EA2TT", QSOs: mustQSOs(t, "QSO: 7030 CW 2017-11-25 2122 EA2TT 599 15 K1IR 599 5")},
+		{CallSign: "HA3LN"},
+	}
+
+	checks := CrossCheckLogs(logs)[0]
+
+	t.Run("text", func(t *testing.T) {
+		r, err := NewLogCheckReport(k1ir, checks, NewCQWWScorer())
+		require.NoError(t, err)
+
+		require.Equal(t, ReportScore{QSOs: 4, Points: 12, Multipliers: 8, Total: 96}, r.Before)
+		require.Equal(t, ReportScore{QSOs: 2, Points: 6, Multipliers: 4, Total: 24}, r.After)
+		require.Len(t, r.Entries, 2)
+		require.Equal(t, CheckBustedExchange, r.Entries[0].Status)
+		require.Equal(t, ActionRemoved, r.Entries[0].Action)
+		require.Equal(t, CheckNotInLog, r.Entries[1].Status)
+
+		var buf bytes.Buffer
+		require.NoError(t, r.WriteText(&buf))
+		require.Equal(t, `Log checking report for K1IR
+Contest: CQ-WW-CW
+
+                  QSOs  Points  Mults     Score
+Claimed                                      24
+Before checking      4      12      8        96
+After checking       2       6      4        24
+
+2 QSOs were removed:
+
+7030 CW 2017-11-25 2122 K1IR 599 5 EA2TT 599 14
+  REMOVED BUSTED-EXCHANGE: copied "14", EA2TT sent "15"
+  EA2TT logged: 7030 CW 2017-11-25 2122 EA2TT 599 15 K1IR 599 5
+
+14030 CW 2017-11-25 2200 K1IR 599 5 HA3LN 599 15
+  REMOVED NOT-IN-LOG: not in the log of HA3LN
+`, buf.String())
+	})
+
+	t.Run("json with penalty", func(t *testing.T) {
+		r, err := NewLogCheckReport(k1ir, checks, NewCQWWScorer(), WithPenalty(CheckNotInLog, 2))
+		require.NoError(t, err)
+		require.Equal(t, ActionPenalized, r.Entries[1].Action)
+		require.Equal(t, 6, r.Entries[1].Penalty)
+		require.Equal(t, ReportScore{QSOs: 2, Points: 0, Multipliers: 4, Total: 0}, r.After)
+
+		var buf bytes.Buffer
+		require.NoError(t, r.WriteJSON(&buf))
+
+		var decoded LogCheckReport
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		require.Equal(t, r.After, decoded.After)
+		require.Len(t, decoded.Entries, 2)
+		require.Equal(t, "HA3LN", decoded.Entries[1].QSO.RxInfo.Callsign)
+		require.Equal(t, "K1IR", decoded.Entries[0].Counterpart.RxInfo.Callsign)
+	})
+}
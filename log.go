@@ -7,6 +7,7 @@ import (
 	"net/mail"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 const (
@@ -25,8 +26,8 @@ var (
 )
 
 // Log is a data structure representing an entire Cabrillo formatted Log file.
-// Data can be parsed into the structure. The long-term plan is to be able to
-// generate a Cabrillo formatted log file from the data structure.
+// Data can be parsed into the structure with ParseLog and written back as a
+// Cabrillo formatted log file with WriteLog.
 type Log struct {
 	Address          Address
 	CallSign         string
@@ -42,7 +43,8 @@ type Log struct {
 	Location         string
 	Name             string
 	OffTimes         []OffTime
-	//TODO: when generating the line, each line is a max of 75 chars long. Use multiple lines. Host station prefixed with `@`
+	// Operators are written on lines of at most 75 characters. The host
+	// station is prefixed with "@".
 	Operators []string
 	QSOs      []QSO
 	SoapBox   []string
@@ -134,6 +136,9 @@ type Address struct {
 
 type options struct {
 	exchangeFields int
+	signalReport   bool
+	// quiet stops the unknown tags from being logged.
+	quiet bool
 }
 
 // ParserOption is used to customize the log parser.
@@ -146,10 +151,19 @@ func WithExchangeFields(exchangeFields int) ParserOption {
 	}
 }
 
+// WithoutSignalReport is used for contests that don't exchange signal reports,
+// like ARRL Sweepstakes. The QSO lines are expected to have no RST columns.
+func WithoutSignalReport() ParserOption {
+	return func(o *options) {
+		o.signalReport = false
+	}
+}
+
 // ParseLog attempts to parse the data from the reader into a Log structure.
 func ParseLog(r io.Reader, opts ...ParserOption) (Log, error) {
 	opt := &options{
 		exchangeFields: 1,
+		signalReport:   true,
 	}
 	for _, o := range opts {
 		o(opt)
@@ -237,18 +251,18 @@ func ParseLog(r io.Reader, opts ...ParserOption) (Log, error) {
 		case "OPERATORS:":
 			l.Operators = append(l.Operators, operatorsField(strings.Join(lineParts[1:], " "))...)
 		case "QSO:":
-			qso, err := NewQSO(line, opt.exchangeFields)
+			qso, err := newQSO(line, opt.exchangeFields, opt.signalReport)
 			if err != nil {
 				return Log{}, newLineError(err, lineNum)
 			}
 			l.QSOs = append(l.QSOs, qso)
 		case "SOAPBOX:":
-			// max line length 75
-			l.SoapBox = append(l.SoapBox, strings.Join(lineParts[1:], " "))
+			// max line length 75. The spacing of the comments is kept.
+			l.SoapBox = append(l.SoapBox, tagValue(line))
 		case "START-OF-LOG:":
 			l.Version = lineParts[1]
 		case "X-QSO:":
-			qso, err := NewQSO(line, opt.exchangeFields)
+			qso, err := newQSO(line, opt.exchangeFields, opt.signalReport)
 			if err != nil {
 				return Log{}, newLineError(err, lineNum)
 			}
@@ -269,13 +283,62 @@ func ParseLog(r io.Reader, opts ...ParserOption) (Log, error) {
 				continue
 			}
 			// we shouldn't be calling out to log here. Should this be an error?
-			log.Printf("unknown tag %q", line)
+			if !opt.quiet {
+				log.Printf("unknown tag %q", line)
+			}
 		}
 	}
 
 	return l, nil
 }
 
+// headerTags are the tags read by ParseLog, besides the CATEGORY- and X- tags.
+var headerTags = map[string]struct{}{
+	"ADDRESS:":                {},
+	"ADDRESS-CITY:":           {},
+	"ADDRESS-COUNTRY:":        {},
+	"ADDRESS-POSTALCODE:":     {},
+	"ADDRESS-STATE-PROVINCE:": {},
+	"CALLSIGN:":               {},
+	"CERTIFICATE:":            {},
+	"CLAIMED-SCORE:":          {},
+	"CLUB:":                   {},
+	"CONTEST:":                {},
+	"CREATED-BY:":             {},
+	"EMAIL:":                  {},
+	"END-OF-LOG:":             {},
+	"GRID-LOCATOR:":           {},
+	"LOCATION:":               {},
+	"NAME:":                   {},
+	"OFFTIME:":                {},
+	"OPERATORS:":              {},
+	"QSO:":                    {},
+	"SOAPBOX:":                {},
+	"START-OF-LOG:":           {},
+	"X-QSO:":                  {},
+}
+
+// knownTag returns true if ParseLog reads the lines starting with the tag,
+// including its trailing colon.
+func knownTag(tag string) bool {
+	tag = strings.ToUpper(tag)
+	if _, ok := headerTags[tag]; ok {
+		return true
+	}
+	return strings.HasPrefix(tag, "CATEGORY-") && strings.HasSuffix(tag, ":") || strings.HasPrefix(tag, "X-")
+}
+
+// tagValue returns the value of a line after its tag, keeping the spacing
+// inside the value.
+func tagValue(line string) string {
+	line = strings.TrimSpace(line)
+	i := strings.IndexFunc(line, unicode.IsSpace)
+	if i == -1 {
+		return ""
+	}
+	return strings.TrimSpace(line[i:])
+}
+
 // The operators field is a space or comma delimited field
 func operatorsField(str string) []string {
 	str = strings.ReplaceAll(str, ",", " ")
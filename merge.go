@@ -0,0 +1,273 @@
+package cabrillo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// HeaderConflict is a header field that has different values in the logs
+// merged by MergeLogs.
+type HeaderConflict struct {
+	// Field is the tag of the field, like "CALLSIGN" or "CATEGORY-POWER".
+	Field string
+	// Values are the values of the field in each of the logs, in the order of
+	// the logs. Logs without the field have an empty value.
+	Values []string
+}
+
+// String returns the conflict like "CALLSIGN: N8BJQ, W1AW".
+func (c HeaderConflict) String() string {
+	var values []string
+	for _, v := range c.Values {
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	return fmt.Sprintf("%s: %s", c.Field, strings.Join(values, ", "))
+}
+
+// TransmitterConflict is a QSO found in several of the logs merged by
+// MergeLogs without telling which transmitter it was made on.
+type TransmitterConflict struct {
+	// QSO is the merged QSO, with the first of the transmitters.
+	QSO QSO
+	// Transmitters are the transmitters the QSO has in the logs that record
+	// transmitters, or else the indexes of the logs it's in.
+	Transmitters []int
+}
+
+// String returns the conflict like "QSO: 7030 CW 2017-11-25 0005 K1IR 599 5
+// EA2TT 599 14 on transmitter 0 or 1".
+func (c TransmitterConflict) String() string {
+	var transmitters []string
+	for _, tx := range c.Transmitters {
+		transmitters = append(transmitters, strconv.Itoa(tx))
+	}
+	return fmt.Sprintf("QSO: %s on transmitter %s", c.QSO, strings.Join(transmitters, " or "))
+}
+
+// MergeLogs merges the logs written by the logging computers of a station, one
+// per operating position, into a single log.
+//
+// The header of the merged log takes each field from the first log that has
+// it. Fields that have different values in several logs are returned as
+// conflicts. The operators, soapbox lines, extensible fields and off times of
+// all the logs are combined.
+//
+// Identical QSO lines, which happen when the logging computers share their QSOs
+// over the network, are only kept once. Logs with a QSO on a transmitter other
+// than 0 record the transmitters, and their QSOs keep them. The QSOs of the
+// other logs are set to the index of their log, unless a log recording
+// transmitters has them too. QSOs left with several transmitters, from
+// different logs, get the first one and are returned as conflicts. The QSOs
+// are then sorted by time with SortQSOs.
+func MergeLogs(logs ...Log) (Log, []HeaderConflict, []TransmitterConflict) {
+	var m headerMerger
+
+	var merged Log
+	merged.Version = m.field("START-OF-LOG", logs, func(l Log) string { return l.Version })
+	merged.Contest = m.field("CONTEST", logs, func(l Log) string { return l.Contest })
+	merged.CallSign = m.field("CALLSIGN", logs, func(l Log) string { return l.CallSign })
+	merged.Location = m.field("LOCATION", logs, func(l Log) string { return l.Location })
+	for _, name := range categoryNames(logs) {
+		name := name
+		value := m.field("CATEGORY-"+name, logs, func(l Log) string { return l.Category(name) })
+		merged.Categories = append(merged.Categories, Category{Name: name, Value: value})
+	}
+	merged.GridLocator = m.field("GRID-LOCATOR", logs, func(l Log) string { return l.GridLocator })
+	claimed := m.field("CLAIMED-SCORE", logs, func(l Log) string {
+		if l.ClaimedScore == 0 {
+			return ""
+		}
+		return strconv.Itoa(l.ClaimedScore)
+	})
+	merged.ClaimedScore, _ = strconv.Atoi(claimed)
+	merged.Club = m.field("CLUB", logs, func(l Log) string { return l.Club })
+	merged.Certificate = m.field("CERTIFICATE", logs, func(l Log) string {
+		if l.Certificate {
+			return "YES"
+		}
+		return "NO"
+	}) != "NO"
+	merged.CreatedBy = m.field("CREATED-BY", logs, func(l Log) string { return l.CreatedBy })
+	merged.Name = m.field("NAME", logs, func(l Log) string { return l.Name })
+	merged.Email = m.field("EMAIL", logs, func(l Log) string { return l.Email })
+	address := m.field("ADDRESS", logs, func(l Log) string { return strings.Join(l.Address.Address, ", ") })
+	for _, l := range logs {
+		if strings.Join(l.Address.Address, ", ") == address {
+			merged.Address.Address = append([]string(nil), l.Address.Address...)
+			break
+		}
+	}
+	merged.Address.City = m.field("ADDRESS-CITY", logs, func(l Log) string { return l.Address.City })
+	merged.Address.StateProvince = m.field("ADDRESS-STATE-PROVINCE", logs, func(l Log) string { return l.Address.StateProvince })
+	merged.Address.PostalCode = m.field("ADDRESS-POSTALCODE", logs, func(l Log) string { return l.Address.PostalCode })
+	merged.Address.Country = m.field("ADDRESS-COUNTRY", logs, func(l Log) string { return l.Address.Country })
+
+	operators := make(map[string]bool)
+	soapBox := make(map[string]bool)
+	offTimes := make(map[OffTime]bool)
+	for _, l := range logs {
+		for _, op := range l.Operators {
+			if !operators[strings.ToUpper(op)] {
+				operators[strings.ToUpper(op)] = true
+				merged.Operators = append(merged.Operators, op)
+			}
+		}
+		for _, s := range l.SoapBox {
+			if !soapBox[s] {
+				soapBox[s] = true
+				merged.SoapBox = append(merged.SoapBox, s)
+			}
+		}
+		for _, f := range l.ExtensibleFields {
+			for _, v := range f.Values {
+				if !containsString(merged.ExtendedField(f.Name), v) {
+					merged.AddExtensibleField(f.Name, v)
+				}
+			}
+		}
+		for _, ot := range l.OffTimes {
+			if !offTimes[ot] {
+				offTimes[ot] = true
+				merged.OffTimes = append(merged.OffTimes, ot)
+			}
+		}
+	}
+
+	recorded := make([]bool, len(logs))
+	for i, l := range logs {
+		recorded[i] = recordsTransmitters(l)
+	}
+	var txConflicts []TransmitterConflict
+	merged.QSOs = mergeQSOs(logs, recorded, &txConflicts, func(l Log) []QSO { return l.QSOs })
+	merged.XQSOs = mergeQSOs(logs, recorded, &txConflicts, func(l Log) []QSO { return l.XQSOs })
+
+	return merged, m.conflicts, txConflicts
+}
+
+// headerMerger merges the header fields of logs and collects the conflicts.
+type headerMerger struct {
+	conflicts []HeaderConflict
+}
+
+// field returns the first non-empty value of the field in the logs, and
+// records a conflict if the logs have different values for it. Values are
+// compared without regard to case.
+func (m *headerMerger) field(name string, logs []Log, value func(Log) string) string {
+	var first string
+	var conflict bool
+	values := make([]string, len(logs))
+	for i, l := range logs {
+		values[i] = strings.TrimSpace(value(l))
+		switch {
+		case values[i] == "":
+		case first == "":
+			first = values[i]
+		case !strings.EqualFold(first, values[i]):
+			conflict = true
+		}
+	}
+
+	if conflict {
+		m.conflicts = append(m.conflicts, HeaderConflict{Field: name, Values: values})
+	}
+	return first
+}
+
+// categoryNames returns the names of the categories of the logs in the order
+// they first appear.
+func categoryNames(logs []Log) []string {
+	var names []string
+	for _, l := range logs {
+		for _, c := range l.Categories {
+			if !containsString(names, c.Name) {
+				names = append(names, c.Name)
+			}
+		}
+	}
+	return names
+}
+
+// mergeQSOs returns the QSOs of the logs without repeated QSO lines, sorted by
+// time, with the transmitters set as described by MergeLogs. recorded tells
+// which logs record transmitters. QSOs with several possible transmitters are
+// added to conflicts.
+func mergeQSOs(logs []Log, recorded []bool, conflicts *[]TransmitterConflict, qsos func(Log) []QSO) []QSO {
+	type mergedQSO struct {
+		qso QSO
+		// recorded are the transmitters of the QSO in the logs that record
+		// them, and logs the indexes of the other logs it's in.
+		recorded, logs []int
+	}
+
+	var merged []*mergedQSO
+	seen := make(map[string]*mergedQSO)
+	for i, l := range logs {
+		for _, q := range qsos(l) {
+			// String leaves out the transmitter, so the QSO is the same in
+			// every log.
+			line := q.String()
+			m, ok := seen[line]
+			if !ok {
+				m = &mergedQSO{qso: q}
+				seen[line] = m
+				merged = append(merged, m)
+			}
+			if recorded[i] {
+				m.recorded = appendInt(m.recorded, q.Transmitter)
+			} else {
+				m.logs = appendInt(m.logs, i)
+			}
+		}
+	}
+
+	result := make([]QSO, 0, len(merged))
+	for _, m := range merged {
+		transmitters := m.recorded
+		if len(transmitters) == 0 {
+			transmitters = m.logs
+		}
+		q := m.qso
+		q.Transmitter = transmitters[0]
+		if len(transmitters) > 1 {
+			*conflicts = append(*conflicts, TransmitterConflict{QSO: q, Transmitters: transmitters})
+		}
+		result = append(result, q)
+	}
+	SortQSOs(result)
+	return result
+}
+
+// recordsTransmitters returns true if a QSO of the log is on a transmitter
+// other than 0, so the log has a transmitter column.
+func recordsTransmitters(l Log) bool {
+	for _, qsos := range [][]QSO{l.QSOs, l.XQSOs} {
+		for _, q := range qsos {
+			if q.Transmitter != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// appendInt appends n to values unless it's already there.
+func appendInt(values []int, n int) []int {
+	for _, v := range values {
+		if v == n {
+			return values
+		}
+	}
+	return append(values, n)
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package cabrillo
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeLogs(t *testing.T) {
+	run := Log{
+		Version:     "3.0",
+		CallSign:    "K1IR",
+		Contest:     "CQ-WW-CW",
+		Certificate: true,
+		Categories: []Category{
+			{Name: CategoryOperator, Value: "MULTI-OP"},
+			{Name: CategoryTransmitter, Value: "TWO"},
+		},
+		Operators: []string{"K1IR", "W1AW"},
+		SoapBox:   []string{"Great conditions"},
+		ExtensibleFields: []ExtensibleField{
+			{Name: "POSITION", Values: []string{"RUN"}},
+		},
+		QSOs: mustQSOs(t,
+			"QSO: 14030 CW 2017-11-25 0000 K1IR 599 5 SQ9E 599 15",
+			"QSO: 14030 CW 2017-11-25 0010 K1IR 599 5 HA9A 599 15",
+			"QSO: 7030 CW 2017-11-25 0005 K1IR 599 5 EA2TT 599 14",
+		),
+	}
+	mult := Log{
+		CallSign:    "k1ir",
+		Contest:     "CQ-WW-SSB",
+		Certificate: true,
+		Categories: []Category{
+			{Name: CategoryOperator, Value: "MULTI-OP"},
+			{Name: CategoryPower, Value: "HIGH"},
+		},
+		Operators: []string{"w1aw", "N1MM"},
+		SoapBox:   []string{"Great conditions"},
+		ExtensibleFields: []ExtensibleField{
+			{Name: "POSITION", Values: []string{"MULT"}},
+		},
+		QSOs: mustQSOs(t,
+			"QSO: 7030 CW 2017-11-25 0005 K1IR 599 5 EA2TT 599 14",
+			"QSO: 21030 CW 2017-11-25 0001 K1IR 599 5 HA3LN 599 15",
+			"QSO: 28030 CW 2017-11-25 0010 K1IR 599 5 SP6CJK 599 15",
+		),
+	}
+
+	merged, conflicts, txConflicts := MergeLogs(run, mult)
+
+	require.Equal(t, []HeaderConflict{
+		{Field: "CONTEST", Values: []string{"CQ-WW-CW", "CQ-WW-SSB"}},
+	}, conflicts)
+	require.Equal(t, "CONTEST: CQ-WW-CW, CQ-WW-SSB", conflicts[0].String())
+
+	// EA2TT is in both logs, neither of which records transmitters.
+	require.Len(t, txConflicts, 1)
+	require.Equal(t, []int{0, 1}, txConflicts[0].Transmitters)
+	require.Equal(t, "QSO: 7030 CW 2017-11-25 0005 K1IR 599 5 EA2TT 599 14 on transmitter 0 or 1", txConflicts[0].String())
+
+	require.Equal(t, "3.0", merged.Version)
+	require.Equal(t, "K1IR", merged.CallSign)
+	require.Equal(t, "CQ-WW-CW", merged.Contest)
+	require.True(t, merged.Certificate)
+	require.Equal(t, []Category{
+		{Name: CategoryOperator, Value: "MULTI-OP"},
+		{Name: CategoryTransmitter, Value: "TWO"},
+		{Name: CategoryPower, Value: "HIGH"},
+	}, merged.Categories)
+	require.Equal(t, []string{"K1IR", "W1AW", "N1MM"}, merged.Operators)
+	require.Equal(t, []string{"Great conditions"}, merged.SoapBox)
+	require.Equal(t, []string{"RUN", "MULT"}, merged.ExtendedField("POSITION"))
+
+	var lines []string
+	for _, q := range merged.QSOs {
+		lines = append(lines, q.String()+" "+strconv.Itoa(q.Transmitter))
+	}
+	require.Equal(t, []string{
+		"14030 CW 2017-11-25 0000 K1IR 599 5 SQ9E 599 15 0",
+		"21030 CW 2017-11-25 0001 K1IR 599 5 HA3LN 599 15 1",
+		"7030 CW 2017-11-25 0005 K1IR 599 5 EA2TT 599 14 0",
+		"14030 CW 2017-11-25 0010 K1IR 599 5 HA9A 599 15 0",
+		"28030 CW 2017-11-25 0010 K1IR 599 5 SP6CJK 599 15 1",
+	}, lines)
+
+	t.Run("conflicting values", func(t *testing.T) {
+		mult := mult
+		mult.Contest = "CQ-WW-CW"
+		mult.CallSign = "W1AW"
+		mult.Certificate = false
+		mult.ClaimedScore = 1000
+
+		merged, conflicts, _ := MergeLogs(run, Log{Certificate: true}, mult)
+		require.Equal(t, []HeaderConflict{
+			{Field: "CALLSIGN", Values: []string{"K1IR", "", "W1AW"}},
+			{Field: "CERTIFICATE", Values: []string{"YES", "YES", "NO"}},
+		}, conflicts)
+		require.Equal(t, "K1IR", merged.CallSign)
+		require.Equal(t, 1000, merged.ClaimedScore)
+		require.Equal(t, 2, merged.QSOs[1].Transmitter)
+	})
+
+	t.Run("existing transmitters", func(t *testing.T) {
+		run := run
+		run.QSOs = mustQSOs(t,
+			"QSO: 14030 CW 2017-11-25 0000 K1IR 599 5 SQ9E 599 15 0",
+			"QSO: 7030 CW 2017-11-25 0005 K1IR 599 5 EA2TT 599 14 0",
+		)
+		mult := mult
+		mult.QSOs = mustQSOs(t,
+			"QSO: 7030 CW 2017-11-25 0005 K1IR 599 5 EA2TT 599 14 1",
+			"QSO: 21030 CW 2017-11-25 0001 K1IR 599 5 HA3LN 599 15 1",
+		)
+
+		// Only the mult log records transmitters, so EA2TT keeps the
+		// transmitter it has there.
+		merged, _, txConflicts := MergeLogs(run, mult)
+		var lines []string
+		for _, q := range merged.QSOs {
+			lines = append(lines, q.String()+" "+strconv.Itoa(q.Transmitter))
+		}
+		require.Equal(t, []string{
+			"14030 CW 2017-11-25 0000 K1IR 599 5 SQ9E 599 15 0",
+			"21030 CW 2017-11-25 0001 K1IR 599 5 HA3LN 599 15 1",
+			"7030 CW 2017-11-25 0005 K1IR 599 5 EA2TT 599 14 1",
+		}, lines)
+		require.Empty(t, txConflicts)
+
+		// Both logs record transmitters, with different ones for EA2TT.
+		run.QSOs = append(run.QSOs, mustQSOs(t, "QSO: 28030 CW 2017-11-25 0010 K1IR 599 5 SP6CJK 599 15 2")...)
+		merged, _, txConflicts = MergeLogs(run, mult)
+		require.Equal(t, 0, merged.QSOs[2].Transmitter)
+		require.Len(t, txConflicts, 1)
+		require.Equal(t, "QSO: 7030 CW 2017-11-25 0005 K1IR 599 5 EA2TT 599 14 on transmitter 0 or 1", txConflicts[0].String())
+	})
+
+	t.Run("no logs", func(t *testing.T) {
+		merged, conflicts, txConflicts := MergeLogs()
+		require.Empty(t, conflicts)
+		require.Empty(t, txConflicts)
+		require.Empty(t, merged.QSOs)
+	})
+}
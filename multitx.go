@@ -0,0 +1,142 @@
+package cabrillo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The rules checked by MultiTransmitterCheck, MultiplierStationCheck and
+// QSYCheck.
+const (
+	RuleTransmitterNumber = "TRANSMITTER-NUMBER"
+	RuleTenMinute         = "TEN-MINUTE-RULE"
+	RuleBandChanges       = "BAND-CHANGES"
+	RuleMultiplierStation = "MULTIPLIER-STATION"
+)
+
+// isMultiTransmitter returns the CATEGORY-TRANSMITTER of a multi-op log with a
+// limited number of transmitters, or false if the log isn't one.
+func isMultiTransmitter(l Log) (string, bool) {
+	if strings.ToUpper(l.Category(CategoryOperator)) != "MULTI-OP" {
+		return "", false
+	}
+	transmitter := strings.ToUpper(l.Category(CategoryTransmitter))
+	return transmitter, transmitter == "ONE" || transmitter == "TWO"
+}
+
+// MultiTransmitterCheck checks the rules for multi-op logs with
+// CATEGORY-TRANSMITTER ONE (multi-single) or TWO (multi-two). The transmitter
+// column must be 0 or 1: the run and the multiplier station for multi-single
+// and the two transmitters for multi-two. Multi-single logs are checked with
+// MultiSingleQSYRule and multi-two logs with MultiTwoQSYRule. The QSOs must be
+// in chronological order. Other logs pass.
+func MultiTransmitterCheck(l Log) []Issue {
+	category, ok := isMultiTransmitter(l)
+	if !ok {
+		return nil
+	}
+
+	var issues []Issue
+	for i, q := range l.QSOs {
+		if q.Transmitter != 0 && q.Transmitter != 1 {
+			issues = append(issues, Issue{
+				QSO:      i,
+				Severity: SeverityError,
+				Rule:     RuleTransmitterNumber,
+				Message:  fmt.Sprintf("transmitter %d used, only 0 and 1 are allowed for CATEGORY-TRANSMITTER %s", q.Transmitter, category),
+			})
+		}
+	}
+
+	rule := MultiSingleQSYRule
+	if category == "TWO" {
+		rule = MultiTwoQSYRule
+	}
+
+	return append(issues, rule.check(l)...)
+}
+
+// MultiplierStationCheck returns a Check verifying that the multiplier station
+// (transmitter 1) of a multi-single log only worked new multipliers, as scored
+// by s. Other logs pass.
+func MultiplierStationCheck(s Scorer) Check {
+	return func(l Log) []Issue {
+		if category, ok := isMultiTransmitter(l); !ok || category != "ONE" {
+			return nil
+		}
+
+		score, err := s.Score(l)
+		if err != nil {
+			return []Issue{{
+				QSO:      -1,
+				Severity: SeverityError,
+				Rule:     RuleMultiplierStation,
+				Message:  fmt.Sprintf("scoring log: %s", err),
+			}}
+		}
+
+		var issues []Issue
+		for i, q := range l.QSOs {
+			if q.Transmitter != 1 || len(score.QSOs[i].Multipliers) > 0 {
+				continue
+			}
+			issues = append(issues, Issue{
+				QSO:      i,
+				Severity: SeverityError,
+				Rule:     RuleMultiplierStation,
+				Message:  fmt.Sprintf("multiplier station worked %s, which is not a new multiplier", q.RxInfo.Callsign),
+			})
+		}
+		return issues
+	}
+}
+
+// TransmitterSummary summarizes the activity of one transmitter of a log.
+type TransmitterSummary struct {
+	Transmitter int
+	QSOs        int
+	// Multipliers is the number of multipliers the transmitter worked first.
+	Multipliers int
+	BandChanges int
+	// Bands is the number of QSOs on each band.
+	Bands map[string]int
+}
+
+// SummarizeTransmitters summarizes the activity of each transmitter of the log
+// using its score, showing which transmitter ran and which one hunted
+// multipliers. The QSOs must be in chronological order. The summaries are
+// ordered by transmitter.
+func SummarizeTransmitters(l Log, s Score) []TransmitterSummary {
+	summaries := make(map[int]*TransmitterSummary)
+	for i, q := range l.QSOs {
+		sum, ok := summaries[q.Transmitter]
+		if !ok {
+			sum = &TransmitterSummary{
+				Transmitter: q.Transmitter,
+				Bands:       make(map[string]int),
+			}
+			summaries[q.Transmitter] = sum
+		}
+
+		sum.QSOs++
+		sum.Bands[q.Band()]++
+		if i < len(s.QSOs) {
+			sum.Multipliers += len(s.QSOs[i].Multipliers)
+		}
+	}
+
+	for _, c := range BandChanges(l) {
+		summaries[c.Transmitter].BandChanges++
+	}
+
+	result := make([]TransmitterSummary, 0, len(summaries))
+	for _, sum := range summaries {
+		result = append(result, *sum)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Transmitter < result[j].Transmitter
+	})
+
+	return result
+}
@@ -0,0 +1,96 @@
+package cabrillo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func multiOpLog(t *testing.T, transmitter string, lines ...string) Log {
+	t.Helper()
+	return Log{
+		CallSign: "K1IR",
+		Categories: []Category{
+			{Name: CategoryOperator, Value: "MULTI-OP"},
+			{Name: CategoryTransmitter, Value: transmitter},
+		},
+		QSOs: mustQSOs(t, lines...),
+	}
+}
+
+func TestMultiTransmitterCheck(t *testing.T) {
+	t.Run("multi-single", func(t *testing.T) {
+		l := multiOpLog(t, "ONE",
+			"QSO: 14030 CW 2017-11-25 0000 K1IR 599 5 SQ9E 599 15 0",
+			"QSO: 7030 CW 2017-11-25 0005 K1IR 599 5 EA2TT 599 14 0",
+			"QSO: 14030 CW 2017-11-25 0020 K1IR 599 5 HA9A 599 15 0",
+			"QSO: 21030 CW 2017-11-25 0021 K1IR 599 5 HA3LN 599 15 1",
+			"QSO: 28030 CW 2017-11-25 0025 K1IR 599 5 SP6CJK 599 15 1",
+			"QSO: 14030 CW 2017-11-25 0030 K1IR 599 5 DL1ABC 599 14 2",
+		)
+
+		require.Equal(t, []Issue{
+			{QSO: 1, Severity: SeverityError, Rule: RuleTenMinute, Message: "transmitter 0 changed from 20M to 40M 5 minutes after moving to 20M at 2017-11-25 0000"},
+			{QSO: 4, Severity: SeverityError, Rule: RuleTenMinute, Message: "transmitter 1 changed from 15M to 10M 4 minutes after moving to 15M at 2017-11-25 0021"},
+			{QSO: 5, Severity: SeverityError, Rule: RuleTransmitterNumber, Message: "transmitter 2 used, only 0 and 1 are allowed for CATEGORY-TRANSMITTER ONE"},
+		}, Validate(l, MultiTransmitterCheck))
+	})
+
+	t.Run("multi-two", func(t *testing.T) {
+		var lines []string
+		for _, qso := range []string{
+			"14030 CW 2017-11-25 0000",
+			"7030 CW 2017-11-25 0001",
+			"14030 CW 2017-11-25 0002",
+			"7030 CW 2017-11-25 0003",
+			"14030 CW 2017-11-25 0004",
+			"7030 CW 2017-11-25 0005",
+			"14030 CW 2017-11-25 0006",
+			"7030 CW 2017-11-25 0007",
+			"14030 CW 2017-11-25 0008",
+			"7030 CW 2017-11-25 0009",
+			"14030 CW 2017-11-25 0100",
+		} {
+			lines = append(lines, "QSO: "+qso+" K1IR 599 5 SQ9E 599 15 1")
+		}
+		l := multiOpLog(t, "TWO", lines...)
+
+		require.Equal(t, []Issue{
+			{QSO: 9, Severity: SeverityError, Rule: RuleBandChanges, Message: "transmitter 1 changed bands 9 times in the hour starting at 2017-11-25 0000, only 8 changes are allowed"},
+		}, Validate(l, MultiTransmitterCheck))
+	})
+
+	t.Run("single-op", func(t *testing.T) {
+		l := multiOpLog(t, "UNLIMITED",
+			"QSO: 14030 CW 2017-11-25 0000 K1IR 599 5 SQ9E 599 15 0",
+			"QSO: 7030 CW 2017-11-25 0001 K1IR 599 5 EA2TT 599 14 5",
+		)
+		require.Empty(t, Validate(l, MultiTransmitterCheck))
+	})
+}
+
+func TestMultiplierStation(t *testing.T) {
+	l := multiOpLog(t, "ONE",
+		"QSO: 7030 CW 2017-11-25 0000 K1IR 599 5 SQ9E 599 15 0",
+		"QSO: 7030 CW 2017-11-25 0001 K1IR 599 5 EA2TT 599 14 1",
+		"QSO: 7030 CW 2017-11-25 0002 K1IR 599 5 SP6CJK 599 15 1",
+		"QSO: 7030 CW 2017-11-25 0003 K1IR 599 5 HA3LN 599 15 0",
+		"QSO: 14030 CW 2017-11-25 0004 K1IR 599 5 SP6CJK 599 15 0",
+	)
+
+	t.Run("check", func(t *testing.T) {
+		require.Equal(t, []Issue{
+			{QSO: 2, Severity: SeverityError, Rule: RuleMultiplierStation, Message: "multiplier station worked SP6CJK, which is not a new multiplier"},
+		}, Validate(l, MultiplierStationCheck(NewCQWWScorer())))
+	})
+
+	t.Run("summary", func(t *testing.T) {
+		score, err := NewCQWWScorer().Score(l)
+		require.NoError(t, err)
+
+		require.Equal(t, []TransmitterSummary{
+			{Transmitter: 0, QSOs: 3, Multipliers: 5, BandChanges: 1, Bands: map[string]int{Band40M: 2, Band20M: 1}},
+			{Transmitter: 1, QSOs: 2, Multipliers: 2, Bands: map[string]int{Band40M: 2}},
+		}, SummarizeTransmitters(l, score))
+	})
+}
@@ -3,6 +3,7 @@ package cabrillo
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 )
@@ -40,3 +41,221 @@ func parseOffTime(str string) (OffTime, error) {
 
 	return ot, nil
 }
+
+// The rules checked by CheckOffTimes and OperatingTimeCheck.
+const (
+	RuleOffTimeOrder   = "OFFTIME-ORDER"
+	RuleOffTimeOverlap = "OFFTIME-OVERLAP"
+	RuleOffTimeLength  = "OFFTIME-LENGTH"
+	RuleQSOInOffTime   = "QSO-IN-OFFTIME"
+	RuleOperatingTime  = "OPERATING-TIME"
+)
+
+// categoryTimeLimits are the operating time limits of the CATEGORY-TIME values.
+var categoryTimeLimits = map[string]time.Duration{
+	"6-HOURS":  6 * time.Hour,
+	"8-HOURS":  8 * time.Hour,
+	"12-HOURS": 12 * time.Hour,
+	"24-HOURS": 24 * time.Hour,
+}
+
+// String returns the off-time in the format of the OFFTIME line.
+func (ot OffTime) String() string {
+	const format = "2006-01-02 1504"
+	return ot.Begin.Format(format) + " " + ot.End.Format(format)
+}
+
+// Duration returns the length of the off-time.
+func (ot OffTime) Duration() time.Duration {
+	return ot.End.Sub(ot.Begin)
+}
+
+// Contains returns true if t falls strictly within the off-time. QSOs logged
+// at the exact begin or end minute are made right before going off or right
+// after coming back.
+func (ot OffTime) Contains(t time.Time) bool {
+	return t.After(ot.Begin) && t.Before(ot.End)
+}
+
+// CheckOffTimes checks that each off-time begins before it ends, that
+// off-times don't overlap and that no QSO was made during an off-time.
+func CheckOffTimes(l Log) []Issue {
+	var issues []Issue
+	for i, ot := range l.OffTimes {
+		if !ot.Begin.Before(ot.End) {
+			issues = append(issues, Issue{
+				QSO:      -1,
+				Severity: SeverityError,
+				Rule:     RuleOffTimeOrder,
+				Message:  fmt.Sprintf("off-time %s ends before it begins", ot),
+			})
+			continue
+		}
+
+		for _, other := range l.OffTimes[i+1:] {
+			if ot.Begin.Before(other.End) && other.Begin.Before(ot.End) {
+				issues = append(issues, Issue{
+					QSO:      -1,
+					Severity: SeverityError,
+					Rule:     RuleOffTimeOverlap,
+					Message:  fmt.Sprintf("off-time %s overlaps off-time %s", ot, other),
+				})
+			}
+		}
+
+		for j, q := range l.QSOs {
+			if ot.Contains(q.Timestamp) {
+				issues = append(issues, Issue{
+					QSO:      j,
+					Severity: SeverityError,
+					Rule:     RuleQSOInOffTime,
+					Message:  fmt.Sprintf("QSO made during off-time %s", ot),
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+// OperatingTime returns the time between the first and the last QSO of the
+// log minus its off-times. Off-times shorter than minOffTime don't count as
+// off-time. If the log doesn't declare any off-times, they are derived from
+// the gaps between QSOs with DeriveOffTimes.
+func OperatingTime(l Log, minOffTime time.Duration) time.Duration {
+	if len(l.QSOs) == 0 {
+		return 0
+	}
+	if len(l.OffTimes) == 0 {
+		_, on := DeriveOffTimes(l.QSOs, minOffTime)
+		return on
+	}
+
+	first, last := l.QSOs[0].Timestamp, l.QSOs[0].Timestamp
+	for _, q := range l.QSOs[1:] {
+		if q.Timestamp.Before(first) {
+			first = q.Timestamp
+		}
+		if q.Timestamp.After(last) {
+			last = q.Timestamp
+		}
+	}
+
+	var counted []OffTime
+	for _, ot := range l.OffTimes {
+		if ot.Duration() >= minOffTime && ot.Duration() > 0 {
+			counted = append(counted, ot)
+		}
+	}
+
+	on := last.Sub(first)
+	for _, ot := range mergeOffTimes(counted) {
+		begin, end := ot.Begin, ot.End
+		if begin.Before(first) {
+			begin = first
+		}
+		if end.After(last) {
+			end = last
+		}
+		if begin.Before(end) {
+			on -= end.Sub(begin)
+		}
+	}
+
+	return on
+}
+
+// OperatingTimeCheck returns a Check that verifies the operating time of the
+// log doesn't exceed the limit of its CATEGORY-TIME. Off-times must be at least
+// minOffTime long to count, as set by the rules of the contest. Logs without a
+// time limited category pass.
+func OperatingTimeCheck(minOffTime time.Duration) Check {
+	return func(l Log) []Issue {
+		category := l.Category(CategoryTime)
+		limit, ok := categoryTimeLimits[strings.ToUpper(category)]
+		if !ok {
+			return nil
+		}
+
+		var issues []Issue
+		for _, ot := range l.OffTimes {
+			if d := ot.Duration(); d > 0 && d < minOffTime {
+				issues = append(issues, Issue{
+					QSO:      -1,
+					Severity: SeverityWarning,
+					Rule:     RuleOffTimeLength,
+					Message:  fmt.Sprintf("off-time %s is shorter than %s and doesn't count", ot, formatDuration(minOffTime)),
+				})
+			}
+		}
+
+		if on := OperatingTime(l, minOffTime); on > limit {
+			issues = append(issues, Issue{
+				QSO:      -1,
+				Severity: SeverityError,
+				Rule:     RuleOperatingTime,
+				Message:  fmt.Sprintf("operated for %s, CATEGORY-TIME %s allows %s", formatDuration(on), category, formatDuration(limit)),
+			})
+		}
+
+		return issues
+	}
+}
+
+// DeriveOffTimes computes the off-times from the gaps between consecutive QSOs
+// that are at least minOffTime long, along with the total operating time. Each
+// off-time begins at the last QSO before the gap and ends at the first QSO
+// after it. The QSOs don't need to be in chronological order.
+func DeriveOffTimes(qsos []QSO, minOffTime time.Duration) ([]OffTime, time.Duration) {
+	if len(qsos) == 0 {
+		return nil, 0
+	}
+
+	times := make([]time.Time, 0, len(qsos))
+	for _, q := range qsos {
+		times = append(times, q.Timestamp)
+	}
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+
+	var offTimes []OffTime
+	on := times[len(times)-1].Sub(times[0])
+	for i := 1; i < len(times); i++ {
+		if gap := times[i].Sub(times[i-1]); gap >= minOffTime {
+			offTimes = append(offTimes, OffTime{Begin: times[i-1], End: times[i]})
+			on -= gap
+		}
+	}
+
+	return offTimes, on
+}
+
+// mergeOffTimes returns the off-times sorted by begin time with overlapping
+// off-times merged.
+func mergeOffTimes(offTimes []OffTime) []OffTime {
+	sorted := make([]OffTime, len(offTimes))
+	copy(sorted, offTimes)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Begin.Before(sorted[j].Begin)
+	})
+
+	var merged []OffTime
+	for _, ot := range sorted {
+		if n := len(merged); n > 0 && !ot.Begin.After(merged[n-1].End) {
+			if ot.End.After(merged[n-1].End) {
+				merged[n-1].End = ot.End
+			}
+			continue
+		}
+		merged = append(merged, ot)
+	}
+
+	return merged
+}
+
+// formatDuration formats a duration in hours and minutes, e.g. "7h05m".
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	return fmt.Sprintf("%dh%02dm", d/time.Hour, (d%time.Hour)/time.Minute)
+}
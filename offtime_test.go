@@ -0,0 +1,116 @@
+package cabrillo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustOffTime(t *testing.T, str string) OffTime {
+	t.Helper()
+	ot, err := parseOffTime(str)
+	require.NoError(t, err)
+	return ot
+}
+
+func TestCheckOffTimes(t *testing.T) {
+	l := Log{
+		OffTimes: []OffTime{
+			mustOffTime(t, "2017-11-25 0300 2017-11-25 0700"),
+			mustOffTime(t, "2017-11-25 0600 2017-11-25 0800"),
+			mustOffTime(t, "2017-11-25 1200 2017-11-25 1100"),
+		},
+		QSOs: mustQSOs(t,
+			"QSO: 7030 CW 2017-11-25 0300 K1IR 599 5 SQ9E 599 15",
+			"QSO: 7030 CW 2017-11-25 0401 K1IR 599 5 EA2TT 599 14",
+			"QSO: 7030 CW 2017-11-25 0800 K1IR 599 5 HA3LN 599 15",
+		),
+	}
+
+	require.Equal(t, []Issue{
+		{QSO: -1, Severity: SeverityError, Rule: RuleOffTimeOverlap, Message: "off-time 2017-11-25 0300 2017-11-25 0700 overlaps off-time 2017-11-25 0600 2017-11-25 0800"},
+		{QSO: -1, Severity: SeverityError, Rule: RuleOffTimeOrder, Message: "off-time 2017-11-25 1200 2017-11-25 1100 ends before it begins"},
+		{QSO: 1, Severity: SeverityError, Rule: RuleQSOInOffTime, Message: "QSO made during off-time 2017-11-25 0300 2017-11-25 0700"},
+	}, Validate(l, CheckOffTimes))
+}
+
+func TestOperatingTime(t *testing.T) {
+	l := Log{
+		Categories: []Category{{Name: CategoryTime, Value: "6-HOURS"}},
+		OffTimes: []OffTime{
+			mustOffTime(t, "2017-11-25 0100 2017-11-25 0300"),
+			mustOffTime(t, "2017-11-25 0200 2017-11-25 0400"),
+			mustOffTime(t, "2017-11-25 0500 2017-11-25 0530"),
+			mustOffTime(t, "2017-11-25 0900 2017-11-25 1200"),
+		},
+		QSOs: mustQSOs(t,
+			"QSO: 7030 CW 2017-11-25 0000 K1IR 599 5 SQ9E 599 15",
+			"QSO: 7030 CW 2017-11-25 0400 K1IR 599 5 EA2TT 599 14",
+			"QSO: 7030 CW 2017-11-25 1000 K1IR 599 5 HA3LN 599 15",
+		),
+	}
+
+	t.Run("operating time", func(t *testing.T) {
+		// 10 hours minus 3 hours off until 0400 and 1 hour off after 0900.
+		require.Equal(t, 6*time.Hour, OperatingTime(l, time.Hour))
+		// The 30 minute off-time counts as well.
+		require.Equal(t, 5*time.Hour+30*time.Minute, OperatingTime(l, 30*time.Minute))
+		require.Equal(t, time.Duration(0), OperatingTime(Log{}, time.Hour))
+	})
+
+	t.Run("within limit", func(t *testing.T) {
+		require.Equal(t, []Issue{
+			{QSO: -1, Severity: SeverityWarning, Rule: RuleOffTimeLength, Message: "off-time 2017-11-25 0500 2017-11-25 0530 is shorter than 1h00m and doesn't count"},
+		}, Validate(l, OperatingTimeCheck(time.Hour)))
+	})
+
+	t.Run("over limit", func(t *testing.T) {
+		over := l
+		over.OffTimes = l.OffTimes[:1]
+		require.Equal(t, []Issue{
+			{QSO: -1, Severity: SeverityError, Rule: RuleOperatingTime, Message: "operated for 8h00m, CATEGORY-TIME 6-HOURS allows 6h00m"},
+		}, Validate(over, OperatingTimeCheck(time.Hour)))
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		unlimited := l
+		unlimited.Categories = nil
+		unlimited.OffTimes = nil
+		require.Empty(t, Validate(unlimited, OperatingTimeCheck(time.Hour)))
+	})
+}
+
+func TestDeriveOffTimes(t *testing.T) {
+	qsos := mustQSOs(t,
+		"QSO: 7030 CW 2017-11-25 0000 K1IR 599 5 SQ9E 599 15",
+		"QSO: 7030 CW 2017-11-25 0030 K1IR 599 5 EA2TT 599 14",
+		"QSO: 7030 CW 2017-11-25 0300 K1IR 599 5 HA3LN 599 15",
+		"QSO: 7030 CW 2017-11-25 0250 K1IR 599 5 HA9A 599 15",
+		"QSO: 7030 CW 2017-11-25 0400 K1IR 599 5 SP6CJK 599 15",
+	)
+
+	offTimes, on := DeriveOffTimes(qsos, time.Hour)
+	require.Equal(t, []OffTime{
+		mustOffTime(t, "2017-11-25 0030 2017-11-25 0250"),
+		mustOffTime(t, "2017-11-25 0300 2017-11-25 0400"),
+	}, offTimes)
+	require.Equal(t, 40*time.Minute, on)
+
+	offTimes, on = DeriveOffTimes(qsos, 3*time.Hour)
+	require.Empty(t, offTimes)
+	require.Equal(t, 4*time.Hour, on)
+
+	offTimes, on = DeriveOffTimes(nil, time.Hour)
+	require.Empty(t, offTimes)
+	require.Equal(t, time.Duration(0), on)
+
+	t.Run("operating time", func(t *testing.T) {
+		l := Log{
+			Categories: []Category{{Name: CategoryTime, Value: "6-HOURS"}},
+			QSOs:       qsos,
+		}
+		require.Equal(t, 40*time.Minute, OperatingTime(l, time.Hour))
+		require.Empty(t, Validate(l, OperatingTimeCheck(time.Hour)))
+	})
+}
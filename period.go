@@ -0,0 +1,30 @@
+package cabrillo
+
+import "time"
+
+// Period is a span of time during which a contest is active. Start is
+// inclusive and End is exclusive, so a contest running from 0000Z Saturday to
+// 2359Z Sunday ends at 0000Z Monday.
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Contains returns true if t falls within the period.
+func (p Period) Contains(t time.Time) bool {
+	return !t.Before(p.Start) && t.Before(p.End)
+}
+
+// inPeriods returns true if t falls within any of the periods. If no periods
+// are given, every time is considered to be within them.
+func inPeriods(periods []Period, t time.Time) bool {
+	if len(periods) == 0 {
+		return true
+	}
+	for _, p := range periods {
+		if p.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
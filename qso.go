@@ -18,6 +18,22 @@ type QSO struct {
 	Transmitter int
 }
 
+// String returns the QSO formatted like the QSO line it was parsed from,
+// without the "QSO:" tag and the transmitter.
+func (q QSO) String() string {
+	fields := []string{q.Frequency, q.Mode, q.Timestamp.Format("2006-01-02 1504")}
+	for _, info := range []Info{q.TxInfo, q.RxInfo} {
+		fields = append(fields, info.Callsign)
+		if info.SignalReport != (RST{}) {
+			fields = append(fields, info.SignalReport.String())
+		}
+		if info.Exchange != "" {
+			fields = append(fields, info.Exchange)
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
 // RST is a signal report.
 type RST struct {
 	Readability int
@@ -69,15 +85,38 @@ type Info struct {
 	Exchange     string
 }
 
+// emptyField is the placeholder for a missing value in a QSO line, which keeps
+// the number of fields the same on every line.
+const emptyField = "-"
+
 // NewQSO parses a line from a cabrillo log into a QSQ struct. exchangeFields
 // specifies the number of fields in the exchange. If the exchange was only a
 // serial number, this should be set to 1. If the exchange is a name, serial
-// number, and QTH all delimited by spaces, set this to 3.
+// number, and QTH all delimited by spaces, set this to 3. A callsign or signal
+// report of "-" is a placeholder for a missing value. A "-" in the exchange is
+// kept, so the exchange fields after it keep their positions.
 func NewQSO(line string, exchangeFields int) (QSO, error) {
+	return newQSO(line, exchangeFields, true)
+}
+
+// newQSO parses a line from a cabrillo log. signalReport specifies whether the
+// sent and received information start with a signal report. Some contests, like
+// ARRL Sweepstakes, don't exchange signal reports.
+func newQSO(line string, exchangeFields int, signalReport bool) (QSO, error) {
 	fields := strings.Fields(line)
 
-	fieldsMin := 9 + exchangeFields*2
-	fieldsMax := 10 + exchangeFields*2
+	rstFields := 0
+	if signalReport {
+		rstFields = 1
+	}
+
+	// Each side of the QSO has a callsign, signal report and exchange.
+	sideFields := 1 + rstFields + exchangeFields
+	txStart := 5
+	rxStart := txStart + sideFields
+
+	fieldsMin := 5 + sideFields*2
+	fieldsMax := fieldsMin + 1
 
 	if len(fields) != fieldsMin && len(fields) != fieldsMax {
 		return QSO{}, fmt.Errorf(
@@ -93,12 +132,12 @@ func NewQSO(line string, exchangeFields int) (QSO, error) {
 		Frequency: fields[1],
 		Mode:      fields[2],
 		TxInfo: Info{
-			Callsign: fields[5],
-			Exchange: strings.Join(fields[7:7+exchangeFields], " "),
+			Callsign: valueOf(fields[txStart]),
+			Exchange: strings.Join(fields[txStart+1+rstFields:rxStart], " "),
 		},
 		RxInfo: Info{
-			Callsign: fields[7+exchangeFields],
-			Exchange: strings.Join(fields[7+exchangeFields+2:7+exchangeFields+2+exchangeFields], " "),
+			Callsign: valueOf(fields[rxStart]),
+			Exchange: strings.Join(fields[rxStart+1+rstFields:fieldsMin], " "),
 		},
 	}
 
@@ -108,14 +147,20 @@ func NewQSO(line string, exchangeFields int) (QSO, error) {
 		return QSO{}, err
 	}
 
-	qso.TxInfo.SignalReport, err = NewRST(fields[6])
-	if err != nil {
-		return QSO{}, fmt.Errorf("parsing tx RST: %w", err)
-	}
+	if signalReport {
+		if rst := fields[txStart+1]; rst != emptyField {
+			qso.TxInfo.SignalReport, err = NewRST(rst)
+			if err != nil {
+				return QSO{}, fmt.Errorf("parsing tx RST: %w", err)
+			}
+		}
 
-	qso.RxInfo.SignalReport, err = NewRST(fields[7+exchangeFields+1])
-	if err != nil {
-		return QSO{}, fmt.Errorf("parsing rx RST: %w", err)
+		if rst := fields[rxStart+1]; rst != emptyField {
+			qso.RxInfo.SignalReport, err = NewRST(rst)
+			if err != nil {
+				return QSO{}, fmt.Errorf("parsing rx RST: %w", err)
+			}
+		}
 	}
 
 	if len(fields) == fieldsMax {
@@ -127,3 +172,21 @@ func NewQSO(line string, exchangeFields int) (QSO, error) {
 
 	return qso, nil
 }
+
+// valueOf returns the value of a field of a QSO line, which is empty for the
+// placeholder of a missing value.
+func valueOf(field string) string {
+	if field == emptyField {
+		return ""
+	}
+	return field
+}
+
+// fieldOf returns the field of a QSO line for the value, which is the
+// placeholder for an empty value.
+func fieldOf(value string) string {
+	if value == "" {
+		return emptyField
+	}
+	return value
+}
@@ -149,5 +149,24 @@ func TestQSO(t *testing.T) {
 
 			require.Equal(t, 2, qso.Transmitter)
 		})
+
+		t.Run("placeholders", func(t *testing.T) {
+			qso, err := NewQSO("QSO:   144 DG 2023-05-27 0010 -             -   3      -      W1AW          -   FN31   -", 2)
+			require.NoError(t, err)
+
+			require.Equal(t, "", qso.TxInfo.Callsign)
+			require.Equal(t, RST{}, qso.TxInfo.SignalReport)
+			require.Equal(t, "3 -", qso.TxInfo.Exchange)
+
+			require.Equal(t, "W1AW", qso.RxInfo.Callsign)
+			require.Equal(t, RST{}, qso.RxInfo.SignalReport)
+			require.Equal(t, "FN31 -", qso.RxInfo.Exchange)
+
+			// A placeholder inside the exchange keeps its position.
+			qso, err = NewQSO("QSO: 7030 CW 2023-05-27 0010 N8BJQ 599 - MI W1AW 599 12 CT", 2)
+			require.NoError(t, err)
+			require.Equal(t, "- MI", qso.TxInfo.Exchange)
+			require.Equal(t, "12 CT", qso.RxInfo.Exchange)
+		})
 	})
 }
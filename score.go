@@ -0,0 +1,247 @@
+package cabrillo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QSOStatus describes how a QSO was treated when scoring a log.
+type QSOStatus string
+
+// The possible statuses of a scored QSO.
+const (
+	// QSOValid is a QSO that was counted.
+	QSOValid QSOStatus = "VALID"
+	// QSODupe is a QSO duplicating an earlier QSO. It counts for no points.
+	QSODupe QSOStatus = "DUPE"
+	// QSOExcluded is an X-QSO. It counts for no points or multipliers.
+	QSOExcluded QSOStatus = "X-QSO"
+	// QSOInvalidExchange is a QSO whose received exchange doesn't satisfy the
+	// rules of the contest. It counts for no points or multipliers.
+	QSOInvalidExchange QSOStatus = "INVALID-EXCHANGE"
+	// QSOUnknownEntity is a QSO with a station that couldn't be resolved to an
+	// entity. It counts for no points, but may count for multipliers that don't
+	// depend on the entity.
+	QSOUnknownEntity QSOStatus = "UNKNOWN-ENTITY"
+	// QSOOutOfPeriod is a QSO made outside of the contest period. It counts for
+	// no points or multipliers.
+	QSOOutOfPeriod QSOStatus = "OUT-OF-PERIOD"
+)
+
+// Multiplier is a multiplier credited by a QSO.
+type Multiplier struct {
+	// Kind is the kind of multiplier, e.g. "ZONE" or "COUNTRY".
+	Kind string
+	// Band is the band the multiplier was credited on. It is empty for
+	// multipliers that only count once per contest.
+	Band  string
+	Value string
+}
+
+// String fullfills the stringer interface.
+func (m Multiplier) String() string {
+	if m.Band == "" {
+		return m.Kind + " " + m.Value
+	}
+	return m.Band + " " + m.Kind + " " + m.Value
+}
+
+// QSOScore is the outcome of scoring a single QSO.
+type QSOScore struct {
+	QSO    QSO
+	Status QSOStatus
+	Points int
+	// Multipliers are the new multipliers credited by this QSO.
+	Multipliers []Multiplier
+	// Reason explains the status of QSOs that weren't counted as valid.
+	Reason string
+}
+
+// Score is the result of scoring a log. QSOs contains an entry for each QSO in
+// the log followed by an entry for each X-QSO.
+type Score struct {
+	QSOs        []QSOScore
+	Points      int
+	Multipliers int
+	Total       int
+}
+
+// Count returns the number of QSOs with the given status.
+func (s Score) Count(status QSOStatus) int {
+	var count int
+	for _, q := range s.QSOs {
+		if q.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// add records the outcome of a QSO, adding its points and multipliers to the
+// totals.
+func (s *Score) add(qs QSOScore) {
+	s.QSOs = append(s.QSOs, qs)
+	s.Points += qs.Points
+	s.Multipliers += len(qs.Multipliers)
+	s.Total = s.Points * s.Multipliers
+}
+
+// Scorer computes the score of a log according to the rules of a contest.
+type Scorer interface {
+	Score(l Log) (Score, error)
+}
+
+type scoreOptions struct {
+	entities *EntityDB
+	periods  []Period
+}
+
+// ScorerOption is used to customize a Scorer.
+type ScorerOption func(*scoreOptions)
+
+// WithEntityDB sets the database used to resolve callsigns to entities. By
+// default, the built-in database returned by NewEntityDB is used.
+func WithEntityDB(db *EntityDB) ScorerOption {
+	return func(o *scoreOptions) {
+		o.entities = db
+	}
+}
+
+// WithPeriods sets the periods during which the contest is active. QSOs outside
+// of the periods aren't counted. By default, all QSOs are considered to be
+// within the contest period.
+func WithPeriods(periods ...Period) ScorerOption {
+	return func(o *scoreOptions) {
+		o.periods = periods
+	}
+}
+
+func newScoreOptions(opts []ScorerOption) *scoreOptions {
+	opt := &scoreOptions{}
+	for _, o := range opts {
+		o(opt)
+	}
+	if opt.entities == nil {
+		opt.entities = NewEntityDB()
+	}
+	return opt
+}
+
+// scorers maps the CONTEST field to a constructor for the contest's scorer.
+var scorers = map[string]func(...ScorerOption) Scorer{
+	"ARRL-DX-CW":  NewARRLDXScorer,
+	"ARRL-DX-SSB": NewARRLDXScorer,
+	"ARRL-SS-CW":  NewSweepstakesScorer,
+	"ARRL-SS-SSB": NewSweepstakesScorer,
+	"CQ-WPX-CW":   NewCQWPXScorer,
+	"CQ-WPX-RTTY": NewCQWPXRTTYScorer,
+	"CQ-WPX-SSB":  NewCQWPXScorer,
+	"CQ-WW-CW":    NewCQWWScorer,
+	"CQ-WW-SSB":   NewCQWWScorer,
+}
+
+// ScorerFor returns the Scorer for the contest named in the CONTEST field of a
+// log.
+func ScorerFor(contest string, opts ...ScorerOption) (Scorer, error) {
+	fn, ok := scorers[strings.ToUpper(strings.TrimSpace(contest))]
+	if !ok {
+		return nil, fmt.Errorf("no scorer for contest %q", contest)
+	}
+	return fn(opts...), nil
+}
+
+// DupeScope determines which QSOs are considered duplicates of each other.
+type DupeScope int
+
+// The scopes in which a station may only be worked once.
+const (
+	// DupePerBand allows working a station once per band.
+	DupePerBand DupeScope = iota
+	// DupePerBandMode allows working a station once per band and mode.
+	DupePerBandMode
+	// DupePerContest allows working a station once during the contest.
+	DupePerContest
+)
+
+// dupeChecker tracks the stations worked so far.
+type dupeChecker struct {
+	scope  DupeScope
+	worked map[string]struct{}
+}
+
+func newDupeChecker(scope DupeScope) *dupeChecker {
+	return &dupeChecker{
+		scope:  scope,
+		worked: make(map[string]struct{}),
+	}
+}
+
+// check records the QSO and returns true if it duplicates an earlier QSO.
+func (d *dupeChecker) check(q QSO) bool {
+	key := strings.ToUpper(q.RxInfo.Callsign)
+	switch d.scope {
+	case DupePerBand:
+		key += " " + q.Band()
+	case DupePerBandMode:
+		key += " " + q.Band() + " " + q.Mode
+	}
+
+	if _, ok := d.worked[key]; ok {
+		return true
+	}
+	d.worked[key] = struct{}{}
+	return false
+}
+
+// FindDupes returns the indexes of the QSOs that duplicate an earlier QSO in the
+// slice.
+func FindDupes(qsos []QSO, scope DupeScope) []int {
+	d := newDupeChecker(scope)
+	var dupes []int
+	for i, q := range qsos {
+		if d.check(q) {
+			dupes = append(dupes, i)
+		}
+	}
+	return dupes
+}
+
+// multiplierTracker tracks the multipliers worked so far.
+type multiplierTracker map[Multiplier]struct{}
+
+// claim returns true if the multiplier hasn't been worked before.
+func (t multiplierTracker) claim(m Multiplier) bool {
+	if _, ok := t[m]; ok {
+		return false
+	}
+	t[m] = struct{}{}
+	return true
+}
+
+// scoreQSOs scores each QSO of the log within the contest period with fn. QSOs
+// outside of the contest period and X-QSOs are added to the score without
+// calling fn.
+func (o *scoreOptions) scoreQSOs(l Log, fn func(QSO) QSOScore) Score {
+	var score Score
+	for _, q := range l.QSOs {
+		if !inPeriods(o.periods, q.Timestamp) {
+			score.add(QSOScore{
+				QSO:    q,
+				Status: QSOOutOfPeriod,
+				Reason: "outside of the contest period",
+			})
+			continue
+		}
+		score.add(fn(q))
+	}
+
+	for _, q := range l.XQSOs {
+		score.add(QSOScore{
+			QSO:    q,
+			Status: QSOExcluded,
+			Reason: "X-QSO",
+		})
+	}
+
+	return score
+}
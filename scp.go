@@ -0,0 +1,189 @@
+package cabrillo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// RuleUnknownCall is the rule raising issues for QSOs with callsigns that
+// aren't in the Super Check Partial database.
+const RuleUnknownCall = "UNKNOWN-CALL"
+
+// cwConfusions are the characters commonly mis-copied for each other in CW
+// because their codes only differ by a dit or a dah.
+var cwConfusions = map[byte]string{
+	'A': "WR",
+	'B': "6DX",
+	'D': "BNU",
+	'E': "I",
+	'G': "W",
+	'H': "S5",
+	'I': "ES",
+	'J': "1",
+	'N': "D",
+	'O': "0",
+	'R': "A",
+	'S': "HI",
+	'U': "DV",
+	'V': "U4",
+	'W': "AG",
+	'X': "B",
+	'0': "O",
+	'1': "J",
+	'4': "V",
+	'5': "H",
+	'6': "B",
+}
+
+// SCPDatabase is a Super Check Partial database: a list of callsigns known to
+// be active in contests, as distributed in MASTER.SCP files.
+type SCPDatabase struct {
+	calls  map[string]struct{}
+	sorted []string
+}
+
+// ParseSCP parses a MASTER.SCP file. The file has one callsign per line. Empty
+// lines and lines starting with "#" are ignored.
+func ParseSCP(r io.Reader) (*SCPDatabase, error) {
+	db := &SCPDatabase{calls: make(map[string]struct{})}
+
+	scanner := bufio.NewScanner(r)
+	var lineNo int
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.ContainsAny(line, " \t") {
+			return nil, fmt.Errorf("line %d: invalid callsign %q", lineNo, line)
+		}
+
+		call := normalizeCall(line)
+		if _, ok := db.calls[call]; ok {
+			continue
+		}
+		db.calls[call] = struct{}{}
+		db.sorted = append(db.sorted, call)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(db.sorted)
+
+	return db, nil
+}
+
+// Len returns the number of callsigns in the database.
+func (db *SCPDatabase) Len() int {
+	return len(db.sorted)
+}
+
+// Contains returns true if the callsign is in the database. Portable callsigns
+// not in the database are looked up by their home call, so N8BJQ/P is found if
+// N8BJQ is.
+func (db *SCPDatabase) Contains(call string) bool {
+	call = normalizeCall(call)
+	if _, ok := db.calls[call]; ok {
+		return true
+	}
+
+	home := scpHomeCall(call)
+	_, ok := db.calls[home]
+	return ok
+}
+
+// Partial returns the callsigns in the database containing the fragment, in
+// alphabetical order. This is the lookup that gives Super Check Partial its
+// name.
+func (db *SCPDatabase) Partial(fragment string) []string {
+	fragment = normalizeCall(fragment)
+	if fragment == "" {
+		return nil
+	}
+
+	var matches []string
+	for _, call := range db.sorted {
+		if strings.Contains(call, fragment) {
+			matches = append(matches, call)
+		}
+	}
+	return matches
+}
+
+// NearMatches returns the callsigns in the database that differ from call by
+// a single character being added, removed or replaced. Callsigns differing by
+// a common CW mis-copy, such as S for H or B for 6, come first.
+func (db *SCPDatabase) NearMatches(call string) []string {
+	call = scpHomeCall(normalizeCall(call))
+
+	var confused, others []string
+	for _, candidate := range db.sorted {
+		if !similarCalls(candidate, call) {
+			continue
+		}
+		if cwMiscopy(candidate, call) {
+			confused = append(confused, candidate)
+		} else {
+			others = append(others, candidate)
+		}
+	}
+
+	return append(confused, others...)
+}
+
+// Check returns a Check that warns about QSOs with callsigns that aren't in
+// the database, suggesting near matches.
+func (db *SCPDatabase) Check() Check {
+	return func(l Log) []Issue {
+		var issues []Issue
+		for i, q := range l.QSOs {
+			if db.Contains(q.RxInfo.Callsign) {
+				continue
+			}
+
+			msg := fmt.Sprintf("%s is not in the Super Check Partial database", q.RxInfo.Callsign)
+			if matches := db.NearMatches(q.RxInfo.Callsign); len(matches) > 0 {
+				msg += fmt.Sprintf(", did you mean %s?", strings.Join(matches, " or "))
+			}
+
+			issues = append(issues, Issue{
+				QSO:      i,
+				Severity: SeverityWarning,
+				Rule:     RuleUnknownCall,
+				Message:  msg,
+			})
+		}
+		return issues
+	}
+}
+
+// scpHomeCall returns the longest part of a portable callsign, which is the
+// home call in all but the rarest cases.
+func scpHomeCall(call string) string {
+	var home string
+	for _, part := range strings.Split(call, "/") {
+		if len(part) > len(home) {
+			home = part
+		}
+	}
+	return home
+}
+
+// cwMiscopy returns true if a and b have the same length and differ by a
+// single character that is commonly mis-copied in CW.
+func cwMiscopy(a, b string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := 0; i < len(a); i++ {
+		if a[i] != b[i] {
+			return strings.IndexByte(cwConfusions[a[i]], b[i]) != -1
+		}
+	}
+	return false
+}
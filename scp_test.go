@@ -0,0 +1,63 @@
+package cabrillo
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSCPDatabase(t *testing.T) {
+	fh, err := os.Open("testdata/master.scp")
+	require.NoError(t, err)
+	defer fh.Close()
+
+	db, err := ParseSCP(fh)
+	require.NoError(t, err)
+	require.Equal(t, 12, db.Len())
+
+	t.Run("contains", func(t *testing.T) {
+		require.True(t, db.Contains("K1IR"))
+		require.True(t, db.Contains("k1ir"))
+		require.True(t, db.Contains("N8BJQ/P"))
+		require.True(t, db.Contains("DL/N8BJQ"))
+		require.False(t, db.Contains("K1IS"))
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		require.Equal(t, []string{"HA3LN", "HA8A", "HA9A"}, db.Partial("HA"))
+		require.Equal(t, []string{"W1AH", "W1AW", "W1AWX"}, db.Partial("W1A"))
+		require.Nil(t, db.Partial(""))
+	})
+
+	t.Run("near matches", func(t *testing.T) {
+		// S is a common mis-copy of H, the others are just one character off.
+		require.Equal(t, []string{"W1AH", "W1AW"}, db.NearMatches("W1AS"))
+		require.Equal(t, []string{"W1AW", "W1AH"}, db.NearMatches("W1AG"))
+		require.Equal(t, []string{"W1AW", "W1AWX"}, db.NearMatches("W1AWY"))
+		require.Equal(t, []string{"SP6CJK"}, db.NearMatches("SPBCJK"))
+		require.Equal(t, []string{"SP6CJK"}, db.NearMatches("SPBCJK/P"))
+		require.Empty(t, db.NearMatches("JA1XYZ"))
+	})
+
+	t.Run("check", func(t *testing.T) {
+		l := Log{
+			CallSign: "K1IR",
+			QSOs: mustQSOs(t,
+				"QSO: 7030 CW 2017-11-25 2121 K1IR 599 5 SQ9E 599 15",
+				"QSO: 7030 CW 2017-11-25 2122 K1IR 599 5 EA2TS 599 14",
+				"QSO: 7030 CW 2017-11-25 2123 K1IR 599 5 JA1XYZ 599 25",
+			),
+		}
+		require.Equal(t, []Issue{
+			{QSO: 1, Severity: SeverityWarning, Rule: RuleUnknownCall, Message: "EA2TS is not in the Super Check Partial database, did you mean EA2TT?"},
+			{QSO: 2, Severity: SeverityWarning, Rule: RuleUnknownCall, Message: "JA1XYZ is not in the Super Check Partial database"},
+		}, Validate(l, db.Check()))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseSCP(strings.NewReader("K1IR\nW1AW W1AX\n"))
+		require.EqualError(t, err, `line 2: invalid callsign "W1AW W1AX"`)
+	})
+}
@@ -0,0 +1,141 @@
+package cabrillo
+
+import "strings"
+
+// ARRLSection is a section of the ARRL Field Organization or of Radio Amateurs
+// of Canada (RAC).
+type ARRLSection struct {
+	Abbreviation string
+	Name         string
+}
+
+// ARRLSections returns the ARRL and RAC sections. These are the multipliers in
+// ARRL Sweepstakes.
+func ARRLSections() []ARRLSection {
+	return []ARRLSection{
+		// Call area 1
+		{"CT", "Connecticut"},
+		{"EMA", "Eastern Massachusetts"},
+		{"ME", "Maine"},
+		{"NH", "New Hampshire"},
+		{"RI", "Rhode Island"},
+		{"VT", "Vermont"},
+		{"WMA", "Western Massachusetts"},
+		// Call area 2
+		{"ENY", "Eastern New York"},
+		{"NLI", "New York City - Long Island"},
+		{"NNJ", "Northern New Jersey"},
+		{"NNY", "Northern New York"},
+		{"SNJ", "Southern New Jersey"},
+		{"WNY", "Western New York"},
+		// Call area 3
+		{"DE", "Delaware"},
+		{"EPA", "Eastern Pennsylvania"},
+		{"MDC", "Maryland - DC"},
+		{"WPA", "Western Pennsylvania"},
+		// Call area 4
+		{"AL", "Alabama"},
+		{"GA", "Georgia"},
+		{"KY", "Kentucky"},
+		{"NC", "North Carolina"},
+		{"NFL", "Northern Florida"},
+		{"PR", "Puerto Rico"},
+		{"SC", "South Carolina"},
+		{"SFL", "Southern Florida"},
+		{"TN", "Tennessee"},
+		{"VA", "Virginia"},
+		{"VI", "Virgin Islands"},
+		{"WCF", "West Central Florida"},
+		// Call area 5
+		{"AR", "Arkansas"},
+		{"LA", "Louisiana"},
+		{"MS", "Mississippi"},
+		{"NM", "New Mexico"},
+		{"NTX", "North Texas"},
+		{"OK", "Oklahoma"},
+		{"STX", "South Texas"},
+		{"WTX", "West Texas"},
+		// Call area 6
+		{"EB", "East Bay"},
+		{"LAX", "Los Angeles"},
+		{"ORG", "Orange"},
+		{"PAC", "Pacific"},
+		{"SB", "Santa Barbara"},
+		{"SCV", "Santa Clara Valley"},
+		{"SDG", "San Diego"},
+		{"SF", "San Francisco"},
+		{"SJV", "San Joaquin Valley"},
+		{"SV", "Sacramento Valley"},
+		// Call area 7
+		{"AK", "Alaska"},
+		{"AZ", "Arizona"},
+		{"EWA", "Eastern Washington"},
+		{"ID", "Idaho"},
+		{"MT", "Montana"},
+		{"NV", "Nevada"},
+		{"OR", "Oregon"},
+		{"UT", "Utah"},
+		{"WWA", "Western Washington"},
+		{"WY", "Wyoming"},
+		// Call area 8
+		{"MI", "Michigan"},
+		{"OH", "Ohio"},
+		{"WV", "West Virginia"},
+		// Call area 9
+		{"IL", "Illinois"},
+		{"IN", "Indiana"},
+		{"WI", "Wisconsin"},
+		// Call area 0
+		{"CO", "Colorado"},
+		{"IA", "Iowa"},
+		{"KS", "Kansas"},
+		{"MN", "Minnesota"},
+		{"MO", "Missouri"},
+		{"ND", "North Dakota"},
+		{"NE", "Nebraska"},
+		{"SD", "South Dakota"},
+		// Canada
+		{"AB", "Alberta"},
+		{"BC", "British Columbia"},
+		{"GH", "Golden Horseshoe"},
+		{"MB", "Manitoba"},
+		{"NB", "New Brunswick"},
+		{"NL", "Newfoundland/Labrador"},
+		{"NS", "Nova Scotia"},
+		{"ONE", "Ontario East"},
+		{"ONN", "Ontario North"},
+		{"ONS", "Ontario South"},
+		{"PE", "Prince Edward Island"},
+		{"QC", "Quebec"},
+		{"SK", "Saskatchewan"},
+		{"TER", "Territories"},
+	}
+}
+
+// isARRLSection returns true if abbr is the abbreviation of an ARRL or RAC
+// section.
+func isARRLSection(abbr string) bool {
+	abbr = strings.ToUpper(abbr)
+	for _, s := range ARRLSections() {
+		if s.Abbreviation == abbr {
+			return true
+		}
+	}
+	return false
+}
+
+// arrlDXStatesProvinces are the multipliers for DX stations in the ARRL
+// International DX contest: the 48 contiguous US states, the District of
+// Columbia and the Canadian provinces and territories.
+var arrlDXStatesProvinces = map[string]struct{}{
+	"AL": {}, "AR": {}, "AZ": {}, "CA": {}, "CO": {}, "CT": {}, "DC": {}, "DE": {},
+	"FL": {}, "GA": {}, "IA": {}, "ID": {}, "IL": {}, "IN": {}, "KS": {}, "KY": {},
+	"LA": {}, "MA": {}, "MD": {}, "ME": {}, "MI": {}, "MN": {}, "MO": {}, "MS": {},
+	"MT": {}, "NC": {}, "ND": {}, "NE": {}, "NH": {}, "NJ": {}, "NM": {}, "NV": {},
+	"NY": {}, "OH": {}, "OK": {}, "OR": {}, "PA": {}, "RI": {}, "SC": {}, "SD": {},
+	"TN": {}, "TX": {}, "UT": {}, "VA": {}, "VT": {}, "WA": {}, "WI": {}, "WV": {},
+	"WY": {},
+
+	"AB": {}, "BC": {}, "MB": {}, "NB": {}, "NL": {}, "NS": {}, "NT": {}, "NU": {},
+	"ON": {}, "PE": {}, "QC": {}, "SK": {}, "YT": {},
+}
@@ -0,0 +1,109 @@
+package cabrillo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The rules checked by SerialSequenceCheck.
+const (
+	RuleInvalidSerial = "INVALID-SERIAL"
+	RuleSerialGap     = "SERIAL-GAP"
+	RuleSerialRepeat  = "SERIAL-REPEAT"
+	RuleSerialReset   = "SERIAL-RESET"
+)
+
+// SerialSequenceCheck returns a Check for contests where the sent exchange
+// includes a serial number, such as CQ WPX or ARRL Sweepstakes. The serial
+// number is the exchange field at index field, starting at 0. Serial numbers
+// must start at 1 and increase by one with each QSO of a transmitter. Only
+// the numbers that were never sent, in a QSO or an X-QSO, are reported as
+// skipped, so a single mistyped serial number doesn't make the following ones
+// look like gaps. The messages quote the QSO line.
+func SerialSequenceCheck(field int) Check {
+	return func(l Log) []Issue {
+		sent := make(map[int]map[int]struct{})
+		markSent := func(q QSO, n int) {
+			if sent[q.Transmitter] == nil {
+				sent[q.Transmitter] = make(map[int]struct{})
+			}
+			sent[q.Transmitter][n] = struct{}{}
+		}
+		for _, q := range l.XQSOs {
+			if n, err := sentSerial(q, field); err == nil {
+				markSent(q, n)
+			}
+		}
+
+		var issues []Issue
+		add := func(i int, severity Severity, rule, format string, args ...interface{}) {
+			issues = append(issues, Issue{
+				QSO:      i,
+				Severity: severity,
+				Rule:     rule,
+				Message:  fmt.Sprintf(format+" (QSO: %s)", append(args, l.QSOs[i])...),
+			})
+		}
+
+		previous := make(map[int]int)
+		highest := make(map[int]int)
+		for i, q := range l.QSOs {
+			n, err := sentSerial(q, field)
+			if err != nil {
+				add(i, SeverityError, RuleInvalidSerial, "%s", err)
+				continue
+			}
+
+			prev := previous[q.Transmitter]
+			previous[q.Transmitter] = n
+			switch {
+			case n == prev:
+				add(i, SeverityWarning, RuleSerialRepeat, "serial number %d sent again", n)
+			case n < prev:
+				add(i, SeverityError, RuleSerialReset, "serial number went back from %d to %d", prev, n)
+			case n > highest[q.Transmitter]+1:
+				// The numbers up to the highest serial number were already
+				// sent or reported. Serial numbers sent in X-QSOs split the
+				// gap into the ranges that were actually skipped.
+				for first := highest[q.Transmitter] + 1; first < n; first++ {
+					if _, ok := sent[q.Transmitter][first]; ok {
+						continue
+					}
+					last := first
+					for last+1 < n {
+						if _, ok := sent[q.Transmitter][last+1]; ok {
+							break
+						}
+						last++
+					}
+
+					if first == last {
+						add(i, SeverityWarning, RuleSerialGap, "serial number %d skipped", first)
+					} else {
+						add(i, SeverityWarning, RuleSerialGap, "serial numbers %d to %d skipped", first, last)
+					}
+					first = last
+				}
+			}
+			markSent(q, n)
+			if n > highest[q.Transmitter] {
+				highest[q.Transmitter] = n
+			}
+		}
+		return issues
+	}
+}
+
+// sentSerial returns the serial number in the sent exchange of the QSO.
+func sentSerial(q QSO, field int) (int, error) {
+	fields := strings.Fields(q.TxInfo.Exchange)
+	if field >= len(fields) {
+		return 0, fmt.Errorf("no serial number in sent exchange %q", q.TxInfo.Exchange)
+	}
+	n, err := strconv.Atoi(fields[field])
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid serial number %q in sent exchange", fields[field])
+	}
+	return n, nil
+}
@@ -0,0 +1,62 @@
+package cabrillo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerialSequenceCheck(t *testing.T) {
+	l := Log{
+		QSOs: mustQSOs(t,
+			"QSO: 7030 CW 2023-05-27 0000 N8BJQ 599 1 SQ9E 599 15",
+			"QSO: 7030 CW 2023-05-27 0001 N8BJQ 599 2 EA2TT 599 14",
+			"QSO: 7030 CW 2023-05-27 0002 N8BJQ 599 2 HA9A 599 15",
+			"QSO: 7030 CW 2023-05-27 0003 N8BJQ 599 5 HA3LN 599 15",
+			"QSO: 7030 CW 2023-05-27 0004 N8BJQ 599 7 SP6CJK 599 15",
+			"QSO: 7030 CW 2023-05-27 0005 N8BJQ 599 1 DL1ABC 599 14",
+			"QSO: 7030 CW 2023-05-27 0006 N8BJQ 599 A HA8A 599 15",
+			"QSO: 7030 CW 2023-05-27 0007 N8BJQ 599 9 OK1ABC 599 15",
+			"QSO: 7030 CW 2023-05-27 0008 N8BJQ 599 14 OK2ABC 599 15",
+			"QSO: 14030 CW 2023-05-27 0000 N8BJQ 599 2 W1AW 599 1 1",
+		),
+		XQSOs: mustQSOs(t,
+			"QSO: 7030 CW 2023-05-27 0004 N8BJQ 599 6 K1IR 599 15",
+			"QSO: 7030 CW 2023-05-27 0007 N8BJQ 599 10 OK3ABC 599 15",
+			"QSO: 7030 CW 2023-05-27 0007 N8BJQ 599 12 OK4ABC 599 15",
+		),
+	}
+
+	require.Equal(t, []Issue{
+		{QSO: 2, Severity: SeverityWarning, Rule: RuleSerialRepeat, Message: "serial number 2 sent again (QSO: 7030 CW 2023-05-27 0002 N8BJQ 599 2 HA9A 599 15)"},
+		{QSO: 3, Severity: SeverityWarning, Rule: RuleSerialGap, Message: "serial numbers 3 to 4 skipped (QSO: 7030 CW 2023-05-27 0003 N8BJQ 599 5 HA3LN 599 15)"},
+		{QSO: 5, Severity: SeverityError, Rule: RuleSerialReset, Message: "serial number went back from 7 to 1 (QSO: 7030 CW 2023-05-27 0005 N8BJQ 599 1 DL1ABC 599 14)"},
+		{QSO: 6, Severity: SeverityError, Rule: RuleInvalidSerial, Message: `invalid serial number "A" in sent exchange (QSO: 7030 CW 2023-05-27 0006 N8BJQ 599 A HA8A 599 15)`},
+		{QSO: 7, Severity: SeverityWarning, Rule: RuleSerialGap, Message: "serial number 8 skipped (QSO: 7030 CW 2023-05-27 0007 N8BJQ 599 9 OK1ABC 599 15)"},
+		{QSO: 8, Severity: SeverityWarning, Rule: RuleSerialGap, Message: "serial number 11 skipped (QSO: 7030 CW 2023-05-27 0008 N8BJQ 599 14 OK2ABC 599 15)"},
+		{QSO: 8, Severity: SeverityWarning, Rule: RuleSerialGap, Message: "serial number 13 skipped (QSO: 7030 CW 2023-05-27 0008 N8BJQ 599 14 OK2ABC 599 15)"},
+		{QSO: 9, Severity: SeverityWarning, Rule: RuleSerialGap, Message: "serial number 1 skipped (QSO: 14030 CW 2023-05-27 0000 N8BJQ 599 2 W1AW 599 1)"},
+	}, Validate(l, SerialSequenceCheck(0)))
+
+	t.Run("field", func(t *testing.T) {
+		qso := func(exchange string) QSO {
+			return QSO{
+				Frequency: "14025",
+				Mode:      "CW",
+				Timestamp: time.Date(1997, time.November, 1, 21, 2, 0, 0, time.UTC),
+				TxInfo:    Info{Callsign: "N5KO", Exchange: exchange},
+				RxInfo:    Info{Callsign: "K9ZO", Exchange: "2 A 69 IL"},
+			}
+		}
+		ss := Log{
+			QSOs: []QSO{qso("1 A 72 CT"), qso("2 A 72 CT"), qso("A 72 CT")},
+		}
+		require.Equal(t, []Issue{
+			{QSO: 2, Severity: SeverityError, Rule: RuleInvalidSerial, Message: `invalid serial number "A" in sent exchange (QSO: 14025 CW 1997-11-01 2102 N5KO A 72 CT K9ZO 2 A 69 IL)`},
+		}, Validate(ss, SerialSequenceCheck(0)))
+		require.Equal(t, []Issue{
+			{QSO: 0, Severity: SeverityError, Rule: RuleInvalidSerial, Message: `no serial number in sent exchange "1" (QSO: 14025 CW 1997-11-01 2102 N5KO 1 K9ZO 2 A 69 IL)`},
+		}, Validate(Log{QSOs: []QSO{qso("1")}}, SerialSequenceCheck(1)))
+	})
+}
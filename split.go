@@ -0,0 +1,136 @@
+package cabrillo
+
+import "strconv"
+
+// LogPart is one of the logs a log is split into.
+type LogPart struct {
+	// Key identifies the part, like the band, the transmitter or the start of
+	// the period of its QSOs.
+	Key string
+	Log Log
+}
+
+// SplitLog splits the log into the logs of the QSOs with the same key. QSOs
+// with an empty key are left out. Each part has the header of the log without
+// the claimed score, which only applies to the whole log. The parts are
+// returned in the order their first QSO appears in the log.
+//
+// QSO lines don't record the operator, so logs can't be split by operator.
+func SplitLog(l Log, key func(QSO) string) []LogPart {
+	var parts []LogPart
+	index := make(map[string]int)
+	part := func(k string) *Log {
+		i, ok := index[k]
+		if !ok {
+			p := copyHeader(l)
+			p.ClaimedScore = 0
+
+			i = len(parts)
+			index[k] = i
+			parts = append(parts, LogPart{Key: k, Log: p})
+		}
+		return &parts[i].Log
+	}
+
+	for _, q := range l.QSOs {
+		if k := key(q); k != "" {
+			p := part(k)
+			p.QSOs = append(p.QSOs, q)
+		}
+	}
+	for _, q := range l.XQSOs {
+		if k := key(q); k != "" {
+			p := part(k)
+			p.XQSOs = append(p.XQSOs, q)
+		}
+	}
+
+	return parts
+}
+
+// copyHeader returns a copy of the header of the log, without the QSOs, that
+// doesn't share its slices with the log.
+func copyHeader(l Log) Log {
+	h := l
+	h.Address.Address = append([]string(nil), l.Address.Address...)
+	h.Categories = append([]Category(nil), l.Categories...)
+	h.ExtensibleFields = nil
+	for _, f := range l.ExtensibleFields {
+		h.ExtensibleFields = append(h.ExtensibleFields, ExtensibleField{
+			Name:   f.Name,
+			Values: append([]string(nil), f.Values...),
+		})
+	}
+	h.OffTimes = append([]OffTime(nil), l.OffTimes...)
+	h.Operators = append([]string(nil), l.Operators...)
+	h.SoapBox = append([]string(nil), l.SoapBox...)
+	h.QSOs = nil
+	h.XQSOs = nil
+	return h
+}
+
+// SplitByBand splits the log into single band logs, like to enter the QSOs of
+// an all band operation in a single band category. The key of each part is the
+// band, and its CATEGORY-BAND is set to the band. QSOs on unknown bands are
+// split by frequency and keep the CATEGORY-BAND of the log.
+func SplitByBand(l Log) []LogPart {
+	bands := make(map[string]bool)
+	parts := SplitLog(l, func(q QSO) string {
+		if band := q.Band(); band != "" {
+			bands[band] = true
+			return band
+		}
+		return q.Frequency
+	})
+	for i := range parts {
+		if bands[parts[i].Key] {
+			// CategoryBand is a valid category, so AddCategory can't fail.
+			_ = parts[i].Log.AddCategory(CategoryBand, parts[i].Key)
+		}
+	}
+	return parts
+}
+
+// SplitByTransmitter splits the log into the logs of each transmitter. The key
+// of each part is the transmitter number.
+func SplitByTransmitter(l Log) []LogPart {
+	return SplitLog(l, func(q QSO) string {
+		return strconv.Itoa(q.Transmitter)
+	})
+}
+
+// SplitByTime splits the log into the logs of the QSOs made during each of the
+// periods. The key of each part is the start of its period, formatted like the
+// time of a QSO line as "2006-01-02 1504". QSOs outside of every period are
+// left out. Each part only keeps the off times that overlap its period.
+func SplitByTime(l Log, periods ...Period) []LogPart {
+	parts := SplitLog(l, func(q QSO) string {
+		for _, p := range periods {
+			if p.Contains(q.Timestamp) {
+				return periodKey(p)
+			}
+		}
+		return ""
+	})
+
+	for i := range parts {
+		for _, p := range periods {
+			if periodKey(p) != parts[i].Key {
+				continue
+			}
+			var offTimes []OffTime
+			for _, ot := range parts[i].Log.OffTimes {
+				if ot.Begin.Before(p.End) && ot.End.After(p.Start) {
+					offTimes = append(offTimes, ot)
+				}
+			}
+			parts[i].Log.OffTimes = offTimes
+			break
+		}
+	}
+	return parts
+}
+
+func periodKey(p Period) string {
+	return p.Start.Format("2006-01-02 1504")
+}
@@ -0,0 +1,127 @@
+package cabrillo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitLog(t *testing.T) {
+	l := Log{
+		CallSign:     "K1IR",
+		ClaimedScore: 1234,
+		Categories: []Category{
+			{Name: CategoryBand, Value: "ALL"},
+			{Name: CategoryTransmitter, Value: "TWO"},
+		},
+		OffTimes: []OffTime{
+			{Begin: time.Date(2017, 11, 25, 1, 0, 0, 0, time.UTC), End: time.Date(2017, 11, 25, 2, 0, 0, 0, time.UTC)},
+			{Begin: time.Date(2017, 11, 26, 1, 0, 0, 0, time.UTC), End: time.Date(2017, 11, 26, 2, 0, 0, 0, time.UTC)},
+		},
+		QSOs: mustQSOs(t,
+			"QSO: 14030 CW 2017-11-25 0000 K1IR 599 5 SQ9E 599 15 0",
+			"QSO: 7030 CW 2017-11-25 0005 K1IR 599 5 EA2TT 599 14 1",
+			"QSO: 14030 CW 2017-11-25 0020 K1IR 599 5 HA9A 599 15 0",
+			"QSO: 3530 CW 2017-11-26 0021 K1IR 599 5 HA3LN 599 15 1",
+			"QSO: 4200 CW 2017-11-26 0025 K1IR 599 5 SP6CJK 599 15 1",
+		),
+		XQSOs: mustQSOs(t,
+			"X-QSO: 14030 CW 2017-11-25 0030 K1IR 599 5 DL1ABC 599 14 0",
+		),
+	}
+
+	calls := func(p LogPart) []string {
+		var calls []string
+		for _, qsos := range [][]QSO{p.Log.QSOs, p.Log.XQSOs} {
+			for _, q := range qsos {
+				calls = append(calls, q.RxInfo.Callsign)
+			}
+		}
+		return calls
+	}
+
+	t.Run("band", func(t *testing.T) {
+		parts := SplitByBand(l)
+		require.Len(t, parts, 4)
+
+		require.Equal(t, "20M", parts[0].Key)
+		require.Equal(t, []string{"SQ9E", "HA9A", "DL1ABC"}, calls(parts[0]))
+		require.Equal(t, "20M", parts[0].Log.Category(CategoryBand))
+		require.Equal(t, "TWO", parts[0].Log.Category(CategoryTransmitter))
+		require.Equal(t, "K1IR", parts[0].Log.CallSign)
+		require.Zero(t, parts[0].Log.ClaimedScore)
+
+		require.Equal(t, "40M", parts[1].Key)
+		require.Equal(t, "40M", parts[1].Log.Category(CategoryBand))
+		require.Equal(t, "80M", parts[2].Key)
+		require.Equal(t, "4200", parts[3].Key)
+		require.Equal(t, "ALL", parts[3].Log.Category(CategoryBand))
+
+		// The log itself is unchanged.
+		require.Equal(t, "ALL", l.Category(CategoryBand))
+		require.Equal(t, 1234, l.ClaimedScore)
+	})
+
+	t.Run("transmitter", func(t *testing.T) {
+		parts := SplitByTransmitter(l)
+		require.Len(t, parts, 2)
+		require.Equal(t, "0", parts[0].Key)
+		require.Equal(t, []string{"SQ9E", "HA9A", "DL1ABC"}, calls(parts[0]))
+		require.Equal(t, "1", parts[1].Key)
+		require.Equal(t, []string{"EA2TT", "HA3LN", "SP6CJK"}, calls(parts[1]))
+		require.Equal(t, "ALL", parts[1].Log.Category(CategoryBand))
+	})
+
+	t.Run("time", func(t *testing.T) {
+		parts := SplitByTime(l,
+			Period{Start: time.Date(2017, 11, 25, 0, 0, 0, 0, time.UTC), End: time.Date(2017, 11, 25, 0, 10, 0, 0, time.UTC)},
+			Period{Start: time.Date(2017, 11, 26, 0, 0, 0, 0, time.UTC), End: time.Date(2017, 11, 27, 0, 0, 0, 0, time.UTC)},
+		)
+		require.Len(t, parts, 2)
+		require.Equal(t, "2017-11-25 0000", parts[0].Key)
+		require.Equal(t, []string{"SQ9E", "EA2TT"}, calls(parts[0]))
+		require.Empty(t, parts[0].Log.OffTimes)
+		require.Equal(t, "2017-11-26 0000", parts[1].Key)
+		require.Equal(t, []string{"HA3LN", "SP6CJK"}, calls(parts[1]))
+		require.Equal(t, l.OffTimes[1:], parts[1].Log.OffTimes)
+	})
+
+	t.Run("copies the header", func(t *testing.T) {
+		l := l
+		l.Address.Address = []string{"1 Main St"}
+		l.Operators = []string{"K1IR", "W1AW"}
+		l.SoapBox = []string{"Great conditions"}
+		l.ExtensibleFields = []ExtensibleField{{Name: "POSITION", Values: []string{"RUN"}}}
+
+		parts := SplitByTransmitter(l)
+		require.Len(t, parts, 2)
+		p := &parts[0].Log
+		p.Address.Address[0] = "2 Main St"
+		p.Operators[0] = "N1MM"
+		p.SoapBox[0] = "Poor conditions"
+		p.ExtensibleFields[0].Values[0] = "MULT"
+		p.OffTimes[0].End = p.OffTimes[0].Begin
+		p.AddExtensibleField("POSITION", "SO2R")
+
+		require.Equal(t, []string{"1 Main St"}, l.Address.Address)
+		require.Equal(t, []string{"K1IR", "W1AW"}, l.Operators)
+		require.Equal(t, []string{"Great conditions"}, l.SoapBox)
+		require.Equal(t, []string{"RUN"}, l.ExtendedField("POSITION"))
+		require.Equal(t, time.Date(2017, 11, 25, 2, 0, 0, 0, time.UTC), l.OffTimes[0].End)
+		require.Equal(t, l.Address, parts[1].Log.Address)
+		require.Equal(t, l.Operators, parts[1].Log.Operators)
+		require.Equal(t, l.ExtensibleFields, parts[1].Log.ExtensibleFields)
+	})
+
+	t.Run("custom key", func(t *testing.T) {
+		parts := SplitLog(l, func(q QSO) string {
+			if q.RxInfo.Exchange == "14" {
+				return "zone 14"
+			}
+			return ""
+		})
+		require.Len(t, parts, 1)
+		require.Equal(t, []string{"EA2TT", "DL1ABC"}, calls(parts[0]))
+	})
+}
@@ -0,0 +1,56 @@
+package cabrillo
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Severity is how serious a validation issue is.
+type Severity string
+
+// The severities of validation issues.
+const (
+	// SeverityError is an issue that breaks the rules of the contest or the
+	// Cabrillo specification.
+	SeverityError Severity = "ERROR"
+	// SeverityWarning is an issue that is suspicious but may be legitimate.
+	SeverityWarning Severity = "WARNING"
+)
+
+// Issue is a problem found while validating a log.
+type Issue struct {
+	// QSO is the index in Log.QSOs of the QSO the issue relates to, or -1 if
+	// the issue relates to the log as a whole.
+	QSO      int
+	Severity Severity
+	// Rule is the name of the rule that raised the issue.
+	Rule    string
+	Message string
+}
+
+// String returns the issue in a human readable form.
+func (i Issue) String() string {
+	if i.QSO < 0 {
+		return fmt.Sprintf("%s %s: %s", i.Severity, i.Rule, i.Message)
+	}
+	return fmt.Sprintf("%s %s: QSO %d: %s", i.Severity, i.Rule, i.QSO+1, i.Message)
+}
+
+// Check validates a log and returns the issues found.
+type Check func(l Log) []Issue
+
+// Validate runs the checks against the log. The issues are returned in the
+// order of the QSOs they relate to, with issues relating to the log as a whole
+// first.
+func Validate(l Log, checks ...Check) []Issue {
+	var issues []Issue
+	for _, check := range checks {
+		issues = append(issues, check(l)...)
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].QSO < issues[j].QSO
+	})
+
+	return issues
+}
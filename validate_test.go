@@ -0,0 +1,35 @@
+package cabrillo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	first := func(l Log) []Issue {
+		return []Issue{
+			{QSO: 1, Severity: SeverityError, Rule: "FIRST", Message: "second QSO"},
+			{QSO: 0, Severity: SeverityWarning, Rule: "FIRST", Message: "first QSO"},
+		}
+	}
+	second := func(l Log) []Issue {
+		return []Issue{
+			{QSO: -1, Severity: SeverityError, Rule: "SECOND", Message: "whole log"},
+			{QSO: 1, Severity: SeverityWarning, Rule: "SECOND", Message: "second QSO"},
+		}
+	}
+
+	issues := Validate(Log{}, first, second)
+	require.Equal(t, []Issue{
+		{QSO: -1, Severity: SeverityError, Rule: "SECOND", Message: "whole log"},
+		{QSO: 0, Severity: SeverityWarning, Rule: "FIRST", Message: "first QSO"},
+		{QSO: 1, Severity: SeverityError, Rule: "FIRST", Message: "second QSO"},
+		{QSO: 1, Severity: SeverityWarning, Rule: "SECOND", Message: "second QSO"},
+	}, issues)
+
+	require.Equal(t, "ERROR SECOND: whole log", issues[0].String())
+	require.Equal(t, "WARNING FIRST: QSO 1: first QSO", issues[1].String())
+
+	require.Empty(t, Validate(Log{}))
+}
@@ -0,0 +1,107 @@
+package cabrillo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Verification is the result of comparing the claimed score of a log with the
+// score computed from its QSOs.
+type Verification struct {
+	Claimed int
+	Score   Score
+	// Difference is the computed score minus the claimed score.
+	Difference int
+	// Dupes are the QSOs not counted because they duplicate an earlier QSO.
+	Dupes []QSOScore
+	// OutOfPeriod are the QSOs not counted because they were made outside of
+	// the contest period.
+	OutOfPeriod []QSOScore
+	// InvalidExchanges are the QSOs not counted because the received exchange
+	// doesn't satisfy the contest rules.
+	InvalidExchanges []QSOScore
+	// UnknownMultipliers are the QSOs with stations that couldn't be resolved
+	// to an entity. They count for no points and no entity multiplier.
+	UnknownMultipliers []QSOScore
+	// Excluded are the X-QSOs of the log.
+	Excluded []QSOScore
+}
+
+// Matches returns true if the computed score equals the claimed score.
+func (v Verification) Matches() bool {
+	return v.Difference == 0
+}
+
+// String returns a human readable report explaining the differences between
+// the claimed and the computed score.
+func (v Verification) String() string {
+	var b strings.Builder
+	fmt.Fprintf(
+		&b,
+		"claimed score %d, computed score %d (%d QSO points x %d multipliers)",
+		v.Claimed,
+		v.Score.Total,
+		v.Score.Points,
+		v.Score.Multipliers,
+	)
+	if v.Matches() {
+		b.WriteString(": scores match\n")
+	} else {
+		fmt.Fprintf(&b, ": difference %+d\n", v.Difference)
+	}
+
+	sections := []struct {
+		description string
+		qsos        []QSOScore
+	}{
+		{"dupes not counted", v.Dupes},
+		{"QSOs outside of the contest period", v.OutOfPeriod},
+		{"QSOs with an invalid exchange", v.InvalidExchanges},
+		{"QSOs with an unknown multiplier", v.UnknownMultipliers},
+		{"X-QSOs not counted", v.Excluded},
+	}
+	for _, s := range sections {
+		if len(s.qsos) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "%d %s:\n", len(s.qsos), s.description)
+		for _, qs := range s.qsos {
+			fmt.Fprintf(&b, "  %s (%s)\n", qs.QSO, qs.Reason)
+		}
+	}
+
+	return b.String()
+}
+
+// VerifyClaimedScore scores the log with the scorer and compares the result with
+// the CLAIMED-SCORE of the log. The returned Verification lists the QSOs that
+// weren't counted, which usually explains why the scores differ.
+func VerifyClaimedScore(l Log, s Scorer) (Verification, error) {
+	score, err := s.Score(l)
+	if err != nil {
+		return Verification{}, err
+	}
+
+	v := Verification{
+		Claimed:    l.ClaimedScore,
+		Score:      score,
+		Difference: score.Total - l.ClaimedScore,
+	}
+
+	for _, qs := range score.QSOs {
+		switch qs.Status {
+		case QSODupe:
+			v.Dupes = append(v.Dupes, qs)
+		case QSOOutOfPeriod:
+			v.OutOfPeriod = append(v.OutOfPeriod, qs)
+		case QSOInvalidExchange:
+			v.InvalidExchanges = append(v.InvalidExchanges, qs)
+		case QSOUnknownEntity:
+			v.UnknownMultipliers = append(v.UnknownMultipliers, qs)
+		case QSOExcluded:
+			v.Excluded = append(v.Excluded, qs)
+		}
+	}
+
+	return v, nil
+}
@@ -0,0 +1,55 @@
+package cabrillo
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyClaimedScore(t *testing.T) {
+	t.Run("matches", func(t *testing.T) {
+		fh, err := os.Open("testdata/k1ir.log")
+		require.NoError(t, err)
+		defer fh.Close()
+
+		l, err := ParseLog(fh)
+		require.NoError(t, err)
+
+		v, err := VerifyClaimedScore(l, NewCQWWScorer())
+		require.NoError(t, err)
+		require.True(t, v.Matches())
+		require.Equal(t, "claimed score 8474, computed score 8474 (223 QSO points x 38 multipliers): scores match\n", v.String())
+	})
+
+	t.Run("differences", func(t *testing.T) {
+		fh, err := os.Open("testdata/cq-wpx-cw.log")
+		require.NoError(t, err)
+		defer fh.Close()
+
+		l, err := ParseLog(fh)
+		require.NoError(t, err)
+
+		period := Period{
+			Start: time.Date(2023, 5, 27, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2023, 5, 27, 1, 2, 0, 0, time.UTC),
+		}
+		v, err := VerifyClaimedScore(l, NewCQWPXScorer(WithPeriods(period)))
+		require.NoError(t, err)
+
+		require.False(t, v.Matches())
+		require.Equal(t, 72, v.Claimed)
+		require.Equal(t, 36, v.Score.Total)
+		require.Equal(t, -36, v.Difference)
+		require.Len(t, v.Dupes, 1)
+		require.Len(t, v.OutOfPeriod, 2)
+		require.Len(t, v.InvalidExchanges, 0)
+		require.Equal(t, "HA8ABC/P", v.OutOfPeriod[0].QSO.RxInfo.Callsign)
+
+		report := v.String()
+		require.Contains(t, report, "claimed score 72, computed score 36 (12 QSO points x 3 multipliers): difference -36\n")
+		require.Contains(t, report, "1 dupes not counted:\n  7025 CW 2023-05-27 0101 N8BJQ 599 5 DL1ABC 599 16 (dupe)\n")
+		require.Contains(t, report, "2 QSOs outside of the contest period:\n")
+	})
+}
@@ -0,0 +1,167 @@
+package cabrillo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MultiplierPrefix is the kind of multiplier counted in the CQ WPX contests.
+const MultiplierPrefix = "PREFIX"
+
+// wpxIgnoredDesignators are the designators that are never counted as a prefix
+// in the CQ WPX contests.
+var wpxIgnoredDesignators = map[string]struct{}{
+	"A":    {},
+	"AM":   {},
+	"LH":   {},
+	"MM":   {},
+	"M":    {},
+	"P":    {},
+	"QRP":  {},
+	"QRPP": {},
+}
+
+// WPXPrefix returns the prefix of the callsign as defined by the CQ WPX contest
+// rules. The prefix is the letter/numeral combination forming the first part of
+// the call, e.g. N8 for N8BJQ or WD8 for WD8BJQ.
+//
+// Portable designators with numerals become the prefix (N8BJQ/KH6 counts as
+// KH6), a designator that is only a numeral replaces the numeral of the home
+// call (N8BJQ/3 counts as N3) and /A, /P, /M, /MM, /AM, /LH, /QRP and /QRPP
+// are ignored. Prefixes without numerals get a zero appended to their first
+// two letters, so N8BJQ/PA and RAEM count as PA0 and RA0. When both parts of
+// the call have the same length, the first one is the designator, so VP2E/W1AW
+// counts as VP2.
+func WPXPrefix(call string) string {
+	var parts []string
+	for _, p := range strings.Split(strings.ToUpper(strings.TrimSpace(call)), "/") {
+		if p == "" {
+			continue
+		}
+		if _, ok := wpxIgnoredDesignators[p]; ok {
+			continue
+		}
+		parts = append(parts, p)
+	}
+
+	switch len(parts) {
+	case 0:
+		return ""
+	case 1:
+		return wpxPrefix(parts[0])
+	}
+
+	// The shorter part is the designator. When both parts have the same
+	// length, it's the first part, as it's usually written.
+	designator, home := parts[0], parts[1]
+	if len(designator) > len(home) {
+		home, designator = designator, home
+	}
+
+	if isDigits(designator) {
+		return strings.TrimRight(wpxPrefix(home), "0123456789") + designator
+	}
+
+	return wpxPrefix(designator)
+}
+
+// wpxPrefix returns everything up to and including the last numeral in str.
+func wpxPrefix(str string) string {
+	i := strings.LastIndexAny(str, "0123456789")
+	if i == -1 {
+		if len(str) > 2 {
+			str = str[:2]
+		}
+		return str + "0"
+	}
+	return str[:i+1]
+}
+
+type wpxScorer struct {
+	opts *scoreOptions
+	rtty bool
+}
+
+// NewCQWPXScorer returns a Scorer implementing the rules of the CW and SSB CQ
+// WPX contests (CQ-WPX-CW and CQ-WPX-SSB). The exchange is a serial number.
+//
+// QSOs with stations on a different continent count 3 points on 20, 15 and 10
+// meters and 6 points on 160, 80 and 40 meters. QSOs with stations on the same
+// continent but in a different country count 1 and 2 points, respectively (2
+// and 4 points between stations in North America). QSOs with stations in the
+// same country count 1 point regardless of band. Each prefix counts as a
+// multiplier once. Stations may be worked once per band.
+func NewCQWPXScorer(opts ...ScorerOption) Scorer {
+	return &wpxScorer{opts: newScoreOptions(opts)}
+}
+
+// NewCQWPXRTTYScorer returns a Scorer implementing the rules of the CQ WPX RTTY
+// contest (CQ-WPX-RTTY). It differs from the CW and SSB contests in that there
+// is no exception for North America and QSOs with stations in the same country
+// count the same as QSOs on the same continent.
+func NewCQWPXRTTYScorer(opts ...ScorerOption) Scorer {
+	return &wpxScorer{opts: newScoreOptions(opts), rtty: true}
+}
+
+func (s *wpxScorer) Score(l Log) (Score, error) {
+	own, err := s.opts.entities.Lookup(stationCallsign(l))
+	if err != nil {
+		return Score{}, fmt.Errorf("resolving entity of the entrant: %w", err)
+	}
+
+	dupes := newDupeChecker(DupePerBand)
+	mults := make(multiplierTracker)
+	return s.opts.scoreQSOs(l, func(q QSO) QSOScore {
+		return s.scoreQSO(own, q, dupes, mults)
+	}), nil
+}
+
+func (s *wpxScorer) scoreQSO(own Entity, q QSO, dupes *dupeChecker, mults multiplierTracker) QSOScore {
+	qs := QSOScore{QSO: q}
+
+	if _, err := strconv.Atoi(strings.TrimSpace(q.RxInfo.Exchange)); err != nil {
+		qs.Status = QSOInvalidExchange
+		qs.Reason = fmt.Sprintf("invalid serial number %q", q.RxInfo.Exchange)
+		return qs
+	}
+
+	if dupes.check(q) {
+		qs.Status = QSODupe
+		qs.Reason = "dupe"
+		return qs
+	}
+
+	if m := (Multiplier{Kind: MultiplierPrefix, Value: WPXPrefix(q.RxInfo.Callsign)}); mults.claim(m) {
+		qs.Multipliers = append(qs.Multipliers, m)
+	}
+
+	entity, err := s.opts.entities.Lookup(q.RxInfo.Callsign)
+	if err != nil {
+		qs.Status = QSOUnknownEntity
+		qs.Reason = err.Error()
+		return qs
+	}
+
+	qs.Status = QSOValid
+
+	// Contacts on the low bands count double.
+	factor := 1
+	switch q.Band() {
+	case Band160M, Band80M, Band40M:
+		factor = 2
+	}
+
+	switch {
+	case entity.Name == own.Name && !s.rtty:
+		qs.Points = 1
+	case entity.Continent != own.Continent:
+		qs.Points = 3 * factor
+	case own.Continent == "NA" && !s.rtty:
+		qs.Points = 2 * factor
+	default:
+		qs.Points = 1 * factor
+	}
+
+	return qs
+}
@@ -0,0 +1,99 @@
+package cabrillo
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWPXPrefix(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"N8BJQ", "N8"},
+		{"WD8BJQ", "WD8"},
+		{"HG19BJQ", "HG19"},
+		{"KC2000ABC", "KC2000"},
+		{"9A1A", "9A1"},
+		{"4X6TT", "4X6"},
+		{"n8bjq", "N8"},
+		{"N8BJQ/P", "N8"},
+		{"N8BJQ/QRP", "N8"},
+		{"N8BJQ/QRPP", "N8"},
+		{"N8BJQ/A", "N8"},
+		{"N8BJQ/LH", "N8"},
+		{"N8BJQ/MM", "N8"},
+		{"N8BJQ/3", "N3"},
+		{"WD8BJQ/3", "WD3"},
+		{"N8BJQ/KH6", "KH6"},
+		{"KH6/N8BJQ", "KH6"},
+		{"N8BJQ/PA", "PA0"},
+		{"PA/N8BJQ", "PA0"},
+		{"F/N8BJQ", "F0"},
+		{"VP2E/W1AW", "VP2"},
+		{"W1AW/VP2E", "W1"},
+		{"RAEM", "RA0"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			require.Equal(t, tt.expected, WPXPrefix(tt.input))
+		})
+	}
+}
+
+func TestCQWPXScorer(t *testing.T) {
+	t.Run("cq-wpx-cw.log", func(t *testing.T) {
+		fh, err := os.Open("testdata/cq-wpx-cw.log")
+		require.NoError(t, err)
+		defer fh.Close()
+
+		l, err := ParseLog(fh)
+		require.NoError(t, err)
+
+		s, err := ScorerFor(l.Contest)
+		require.NoError(t, err)
+
+		score, err := s.Score(l)
+		require.NoError(t, err)
+
+		statuses := make([]QSOStatus, 0, len(score.QSOs))
+		for _, qs := range score.QSOs {
+			statuses = append(statuses, qs.Status)
+		}
+		require.Equal(t, []QSOStatus{
+			QSOValid,
+			QSOValid,
+			QSOValid,
+			QSOValid,
+			QSODupe,
+			QSOValid,
+			QSOInvalidExchange,
+		}, statuses)
+
+		// 3 (DL1ABC 20M) + 1 (W1AW) + 2 (VE3AQ 20M) + 6 (DL1ABC 40M) + 6 (HA8ABC/P 40M)
+		require.Equal(t, 18, score.Points)
+		// DL1, W1, VE3, HA8
+		require.Equal(t, 4, score.Multipliers)
+		require.Equal(t, 72, score.Total)
+	})
+
+	t.Run("rtty", func(t *testing.T) {
+		l := Log{
+			CallSign: "N8BJQ",
+			QSOs: []QSO{
+				{Frequency: "7080", Mode: "RY", RxInfo: Info{Callsign: "W1AW", Exchange: "1"}},
+				{Frequency: "7080", Mode: "RY", RxInfo: Info{Callsign: "VE3AQ", Exchange: "1"}},
+				{Frequency: "14080", Mode: "RY", RxInfo: Info{Callsign: "DL1ABC", Exchange: "1"}},
+			},
+		}
+
+		score, err := NewCQWPXRTTYScorer().Score(l)
+		require.NoError(t, err)
+		require.Equal(t, 2+2+3, score.Points)
+		require.Equal(t, 3, score.Multipliers)
+	})
+}
@@ -0,0 +1,267 @@
+package cabrillo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// maxLengthOperators is the maximum length of an "OPERATORS:" line. Longer
+// lists of operators are written on several lines.
+const maxLengthOperators = 75
+
+// qsoTemplate gives the minimum widths of the callsign and exchange columns of
+// the QSO lines of a contest, as in the QSO templates of the specification.
+type qsoTemplate struct {
+	call     int
+	exchange []int
+}
+
+// defaultQSOTemplate is the template of most contests: a callsign, a signal
+// report and a single exchange field like a serial number or a zone.
+var defaultQSOTemplate = qsoTemplate{call: 13, exchange: []int{6}}
+
+// qsoTemplates are the templates of contests with QSO lines that differ from
+// defaultQSOTemplate.
+var qsoTemplates = map[string]qsoTemplate{
+	// nr p ck sec
+	"ARRL-SS-CW":  {call: 10, exchange: []int{4, 1, 2, 3}},
+	"ARRL-SS-SSB": {call: 10, exchange: []int{4, 1, 2, 3}},
+}
+
+// WriteLog writes the log in the canonical Cabrillo format, so logs holding
+// the same data are written the same way:
+//
+//   - The header fields are written with upper case tags in the order of the
+//     specification, followed by the QSO and X-QSO lines and a single
+//     END-OF-LOG.
+//   - The columns of the QSO lines are aligned, using the widths of the QSO
+//     template of the contest as minimum widths.
+//   - Lines end with a line feed and have no trailing whitespace.
+//   - Every QSO line has the same number of fields. Missing values, like the
+//     signal reports and exchange fields some QSOs don't have, are written
+//     as "-". ParseLog reads a "-" callsign or signal report back as missing,
+//     and keeps a "-" exchange field in the exchange.
+//
+// CERTIFICATE is only written when it is NO, the default being YES. The
+// transmitter column is only written when a QSO has a transmitter other than 0
+// or the log is a multi-transmitter log. Logs without a version are written as
+// version 3.0.
+func WriteLog(w io.Writer, l Log) error {
+	return writeLog(w, l, nil)
+}
+
+// FormatLog reads a log in the Cabrillo format from r and writes it to w in
+// the canonical format of WriteLog. Unlike ParseLog followed by WriteLog, it
+// keeps the lines ParseLog doesn't read, like lines with the tags of other
+// versions of the specification: they are written as they are after the
+// header fields.
+func FormatLog(w io.Writer, r io.Reader, opts ...ParserOption) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	// The unknown tags are kept, so they aren't logged.
+	quiet := func(o *options) { o.quiet = true }
+	l, err := ParseLog(bytes.NewReader(b), append(opts[:len(opts):len(opts)], quiet)...)
+	if err != nil {
+		return err
+	}
+
+	var unknown []string
+	for _, line := range strings.Split(string(b), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || knownTag(fields[0]) {
+			continue
+		}
+		unknown = append(unknown, strings.TrimRightFunc(line, unicode.IsSpace))
+	}
+
+	return writeLog(w, l, unknown)
+}
+
+// writeLog writes the log like WriteLog, with the extra lines after the
+// header fields.
+func writeLog(w io.Writer, l Log, extra []string) error {
+	ew := &errWriter{w: w}
+
+	tag := func(name, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			ew.printf("%s: %s\n", strings.ToUpper(name), value)
+		}
+	}
+
+	tag("START-OF-LOG", firstNonEmpty(l.Version, "3.0"))
+	tag("CONTEST", l.Contest)
+	tag("CALLSIGN", l.CallSign)
+	tag("LOCATION", l.Location)
+	for _, c := range l.Categories {
+		tag("CATEGORY-"+c.Name, c.Value)
+	}
+	tag("GRID-LOCATOR", l.GridLocator)
+	if l.ClaimedScore != 0 {
+		tag("CLAIMED-SCORE", strconv.Itoa(l.ClaimedScore))
+	}
+	tag("CLUB", l.Club)
+	if !l.Certificate {
+		tag("CERTIFICATE", "NO")
+	}
+	tag("CREATED-BY", l.CreatedBy)
+	tag("NAME", l.Name)
+	tag("EMAIL", l.Email)
+	for _, a := range l.Address.Address {
+		tag("ADDRESS", a)
+	}
+	tag("ADDRESS-CITY", l.Address.City)
+	tag("ADDRESS-STATE-PROVINCE", l.Address.StateProvince)
+	tag("ADDRESS-POSTALCODE", l.Address.PostalCode)
+	tag("ADDRESS-COUNTRY", l.Address.Country)
+	for _, line := range operatorLines(l.Operators) {
+		tag("OPERATORS", line)
+	}
+	for _, s := range l.SoapBox {
+		tag("SOAPBOX", s)
+	}
+	for _, f := range l.ExtensibleFields {
+		for _, v := range f.Values {
+			tag("X-"+f.Name, v)
+		}
+	}
+	for _, ot := range l.OffTimes {
+		tag("OFFTIME", ot.String())
+	}
+	for _, line := range extra {
+		ew.printf("%s\n", line)
+	}
+
+	// QSO lines aren't trimmed to keep the frequency column aligned.
+	cols := newQSOColumns(l)
+	for _, q := range l.QSOs {
+		ew.printf("QSO: %s\n", cols.line(q))
+	}
+	for _, q := range l.XQSOs {
+		ew.printf("X-QSO: %s\n", cols.line(q))
+	}
+
+	ew.printf("END-OF-LOG:\n")
+	return ew.err
+}
+
+// qsoColumns are the widths of the columns of the QSO lines of a log.
+type qsoColumns struct {
+	frequency    int
+	mode         int
+	call         int
+	signalReport bool
+	exchange     []int
+	transmitter  bool
+}
+
+// newQSOColumns returns the columns wide enough for all the QSOs of the log,
+// and at least as wide as the QSO template of its contest.
+func newQSOColumns(l Log) *qsoColumns {
+	template, ok := qsoTemplates[strings.ToUpper(strings.TrimSpace(l.Contest))]
+	if !ok {
+		template = defaultQSOTemplate
+	}
+
+	c := &qsoColumns{
+		frequency:   5,
+		mode:        2,
+		call:        template.call,
+		exchange:    append([]int(nil), template.exchange...),
+		transmitter: hasTransmitters(l),
+	}
+	for _, qsos := range [][]QSO{l.QSOs, l.XQSOs} {
+		for _, q := range qsos {
+			c.frequency = maxInt(c.frequency, len(q.Frequency))
+			c.mode = maxInt(c.mode, len(q.Mode))
+			for _, info := range []Info{q.TxInfo, q.RxInfo} {
+				c.call = maxInt(c.call, len(info.Callsign))
+				c.signalReport = c.signalReport || info.SignalReport != (RST{})
+				for i, f := range strings.Fields(info.Exchange) {
+					if i == len(c.exchange) {
+						c.exchange = append(c.exchange, defaultQSOTemplate.exchange[0])
+					}
+					c.exchange[i] = maxInt(c.exchange[i], len(f))
+				}
+			}
+		}
+	}
+
+	return c
+}
+
+// line returns the QSO line of the QSO without the tag.
+func (c *qsoColumns) line(q QSO) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%*s %-*s %s", c.frequency, fieldOf(q.Frequency), c.mode, fieldOf(q.Mode), q.Timestamp.Format("2006-01-02 1504"))
+	for _, info := range []Info{q.TxInfo, q.RxInfo} {
+		fmt.Fprintf(&b, " %-*s", c.call, fieldOf(info.Callsign))
+		if c.signalReport {
+			rst := emptyField
+			if info.SignalReport != (RST{}) {
+				rst = info.SignalReport.String()
+			}
+			fmt.Fprintf(&b, " %-3s", rst)
+		}
+
+		fields := strings.Fields(info.Exchange)
+		for i, width := range c.exchange {
+			f := emptyField
+			if i < len(fields) {
+				f = fields[i]
+			}
+			fmt.Fprintf(&b, " %-*s", width, f)
+		}
+	}
+	if c.transmitter {
+		fmt.Fprintf(&b, " %d", q.Transmitter)
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// operatorLines splits the operators into lines of at most maxLengthOperators
+// characters.
+func operatorLines(operators []string) []string {
+	var lines []string
+	var line string
+	for _, op := range operators {
+		if line != "" && len(line)+1+len(op) > maxLengthOperators {
+			lines = append(lines, line)
+			line = ""
+		}
+		line = joinNonEmpty(line, op)
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// hasTransmitters returns true if the QSO lines of the log need the
+// transmitter column.
+func hasTransmitters(l Log) bool {
+	if _, ok := isMultiTransmitter(l); ok {
+		return true
+	}
+	for _, qsos := range [][]QSO{l.QSOs, l.XQSOs} {
+		for _, q := range qsos {
+			if q.Transmitter != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,300 @@
+package cabrillo
+
+import (
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteLog(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		tests := []struct {
+			file string
+			opts []ParserOption
+		}{
+			{"allfields.log", nil},
+			{"arrl-ss-cw.log", []ParserOption{WithExchangeFields(4), WithoutSignalReport()}},
+			{"cq-wpx-cw.log", nil},
+			{"cq-ww-dx.log", nil},
+			{"generated.log", []ParserOption{WithExchangeFields(2)}},
+			{"k1ir.log", nil},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.file, func(t *testing.T) {
+				fh, err := os.Open("testdata/" + tt.file)
+				require.NoError(t, err)
+				defer fh.Close()
+
+				l, err := ParseLog(fh, tt.opts...)
+				require.NoError(t, err)
+
+				var buf strings.Builder
+				require.NoError(t, WriteLog(&buf, l))
+
+				written, err := ParseLog(strings.NewReader(buf.String()), tt.opts...)
+				require.NoError(t, err)
+				require.Equal(t, l, written)
+			})
+		}
+	})
+
+	t.Run("missing fields", func(t *testing.T) {
+		fh, err := os.Open("testdata/contest.adi")
+		require.NoError(t, err)
+		defer fh.Close()
+
+		l, err := ParseADIF(fh, Log{CallSign: "N8BJQ", Certificate: true})
+		require.NoError(t, err)
+
+		var buf strings.Builder
+		require.NoError(t, WriteLog(&buf, l))
+		require.Contains(t, buf.String(), "\nQSO:   144 DG 2023-05-27 0010 N8BJQ         -   3      -      W1AW          -   FN31   -\n")
+
+		// The placeholders of the missing exchange fields are kept in the
+		// exchange.
+		written, err := ParseLog(strings.NewReader(buf.String()), WithExchangeFields(2))
+		require.NoError(t, err)
+		require.Len(t, written.QSOs, len(l.QSOs))
+		for i, q := range written.QSOs {
+			require.Equal(t, l.QSOs[i].TxInfo.Callsign, q.TxInfo.Callsign)
+			require.Equal(t, l.QSOs[i].RxInfo.SignalReport, q.RxInfo.SignalReport)
+		}
+		require.Equal(t, "3 -", written.QSOs[2].TxInfo.Exchange)
+		require.Equal(t, "7 25", written.QSOs[3].RxInfo.Exchange)
+	})
+
+	t.Run("placeholder inside the exchange", func(t *testing.T) {
+		q, err := NewQSO("QSO: 7030 CW 2023-05-27 0010 N8BJQ 599 - MI W1AW 599 12 CT", 2)
+		require.NoError(t, err)
+		l := Log{QSOs: []QSO{q}}
+
+		var buf strings.Builder
+		require.NoError(t, WriteLog(&buf, l))
+		require.Contains(t, buf.String(), "\nQSO:  7030 CW 2023-05-27 0010 N8BJQ         599 -      MI     W1AW          599 12     CT\n")
+
+		written, err := ParseLog(strings.NewReader(buf.String()), WithExchangeFields(2))
+		require.NoError(t, err)
+		require.Equal(t, l.QSOs, written.QSOs)
+	})
+
+	t.Run("output", func(t *testing.T) {
+		l := Log{
+			CallSign:    "N8BJQ",
+			Contest:     "CQ-WPX-CW",
+			Certificate: true,
+			Operators:   []string{"N8BJQ", strings.Repeat("A", 40), strings.Repeat("B", 40), "@K8ABC"},
+			OffTimes: []OffTime{{
+				Begin: time.Date(2023, time.May, 27, 6, 0, 0, 0, time.UTC),
+				End:   time.Date(2023, time.May, 27, 7, 30, 0, 0, time.UTC),
+			}},
+			QSOs:  mustQSOs(t, "QSO: 14025 CW 2023-05-27 0001 N8BJQ 599 1 DL1ABC 599 12"),
+			XQSOs: mustQSOs(t, "QSO: 14025 CW 2023-05-27 0002 N8BJQ 599 2 DL1XYZ 599 7"),
+		}
+
+		var buf strings.Builder
+		require.NoError(t, WriteLog(&buf, l))
+		require.Equal(t, `START-OF-LOG: 3.0
+CONTEST: CQ-WPX-CW
+CALLSIGN: N8BJQ
+OPERATORS: N8BJQ AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA
+OPERATORS: BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB @K8ABC
+OFFTIME: 2023-05-27 0600 2023-05-27 0730
+QSO: 14025 CW 2023-05-27 0001 N8BJQ         599 1      DL1ABC        599 12
+X-QSO: 14025 CW 2023-05-27 0002 N8BJQ         599 2      DL1XYZ        599 7
+END-OF-LOG:
+`, buf.String())
+
+		l.QSOs[0].Transmitter = 1
+		buf.Reset()
+		require.NoError(t, WriteLog(&buf, l))
+		require.Contains(t, buf.String(), "QSO: 14025 CW 2023-05-27 0001 N8BJQ         599 1      DL1ABC        599 12     1\n")
+		require.Contains(t, buf.String(), "X-QSO: 14025 CW 2023-05-27 0002 N8BJQ         599 2      DL1XYZ        599 7      0\n")
+	})
+
+	t.Run("canonical", func(t *testing.T) {
+		data, err := os.ReadFile("testdata/cq-wpx-cw.log")
+		require.NoError(t, err)
+
+		l, err := ParseLog(strings.NewReader(string(data)))
+		require.NoError(t, err)
+
+		var buf strings.Builder
+		require.NoError(t, WriteLog(&buf, l))
+		require.Equal(t, string(data), buf.String())
+	})
+
+	t.Run("normalizes", func(t *testing.T) {
+		input := "start-of-log: 3.0\r\n" +
+			"callsign: n8bjq  \r\n" +
+			"category-mode: CW\r\n" +
+			"Contest: CQ-WPX-CW\r\n" +
+			"qso: 14025 CW 2023-05-27 0001 N8BJQ 599 1 DL1ABC 599 12\r\n" +
+			"QSO:   7025  CW  2023-05-27 0100  N8BJQ  599  10  HA8ABC/P  599  105   \r\n" +
+			"END-OF-LOG:\r\n" +
+			"END-OF-LOG:\r\n"
+
+		l, err := ParseLog(strings.NewReader(input))
+		require.NoError(t, err)
+
+		var buf strings.Builder
+		require.NoError(t, WriteLog(&buf, l))
+		require.Equal(t, `START-OF-LOG: 3.0
+CONTEST: CQ-WPX-CW
+CALLSIGN: n8bjq
+CATEGORY-MODE: CW
+QSO: 14025 CW 2023-05-27 0001 N8BJQ         599 1      DL1ABC        599 12
+QSO:  7025 CW 2023-05-27 0100 N8BJQ         599 10     HA8ABC/P      599 105
+END-OF-LOG:
+`, buf.String())
+
+		// Formatting is idempotent.
+		formatted, err := ParseLog(strings.NewReader(buf.String()))
+		require.NoError(t, err)
+		var again strings.Builder
+		require.NoError(t, WriteLog(&again, formatted))
+		require.Equal(t, buf.String(), again.String())
+	})
+
+	t.Run("contest template", func(t *testing.T) {
+		fh, err := os.Open("testdata/arrl-ss-cw.log")
+		require.NoError(t, err)
+		defer fh.Close()
+
+		l, err := ParseLog(fh, WithExchangeFields(4), WithoutSignalReport())
+		require.NoError(t, err)
+
+		var buf strings.Builder
+		require.NoError(t, WriteLog(&buf, l))
+		require.Contains(t, buf.String(), "\nQSO: 21042 CW 1997-11-01 2102 N5KO       1    B 74 STX K9ZO       2    A 69 IL\n")
+		require.Contains(t, buf.String(), "\nQSO:  7042 CW 1997-11-01 2201 N5KO       4    B 74 STX VE3AQ      12   U 88 ONS\n")
+	})
+
+	t.Run("wide columns", func(t *testing.T) {
+		l := Log{QSOs: mustQSOs(t,
+			"QSO: 14025 CW 2023-05-27 0001 N8BJQ 599 1 DL1ABC 599 12",
+			"QSO: 1296000 CW 2023-05-27 0002 N8BJQ 599 2 VK2/DL1ABCDEFGH 599 1234567",
+		)}
+
+		var buf strings.Builder
+		require.NoError(t, WriteLog(&buf, l))
+		require.Contains(t, buf.String(), `
+QSO:   14025 CW 2023-05-27 0001 N8BJQ           599 1       DL1ABC          599 12
+QSO: 1296000 CW 2023-05-27 0002 N8BJQ           599 2       VK2/DL1ABCDEFGH 599 1234567
+`)
+	})
+}
+
+func TestFormatLog(t *testing.T) {
+	const input = `START-OF-LOG: 3.0
+CONTEST: ARRL-DX-CW
+CALLSIGN: N8BJQ
+ARRL-SECTION: MI
+SOAPBOX: Two  spaces   and three
+SOAPBOX: ` + "\t" + `Indented
+some stray line
+QSO: 14025 CW 2023-02-18 0001 N8BJQ 599 MI DL1ABC 599 100
+END-OF-LOG:
+`
+
+	// The unknown tags that are kept aren't logged.
+	var logged strings.Builder
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	var buf strings.Builder
+	require.NoError(t, FormatLog(&buf, strings.NewReader(input)))
+	require.Empty(t, logged.String())
+	require.Equal(t, `START-OF-LOG: 3.0
+CONTEST: ARRL-DX-CW
+CALLSIGN: N8BJQ
+SOAPBOX: Two  spaces   and three
+SOAPBOX: Indented
+ARRL-SECTION: MI
+some stray line
+QSO: 14025 CW 2023-02-18 0001 N8BJQ         599 MI     DL1ABC        599 100
+END-OF-LOG:
+`, buf.String())
+
+	t.Run("lossless", func(t *testing.T) {
+		tests := []struct {
+			file  string
+			input string
+			opts  []ParserOption
+		}{
+			{file: "allfields.log"},
+			{file: "arrl-ss-cw.log", opts: []ParserOption{WithExchangeFields(4), WithoutSignalReport()}},
+			{file: "cq-wpx-cw.log"},
+			{file: "cq-ww-dx.log"},
+			{file: "generated.log", opts: []ParserOption{WithExchangeFields(2)}},
+			{file: "k1ir.log"},
+			{file: "unknown tags", input: input},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.file, func(t *testing.T) {
+				if tt.input == "" {
+					data, err := os.ReadFile("testdata/" + tt.file)
+					require.NoError(t, err)
+					tt.input = string(data)
+				}
+
+				var buf strings.Builder
+				require.NoError(t, FormatLog(&buf, strings.NewReader(tt.input), tt.opts...))
+
+				written := make(map[string]bool)
+				var operators []string
+				var qsos int
+				for _, line := range strings.Split(buf.String(), "\n") {
+					written[formatKey(line)] = true
+					fields := strings.Fields(line)
+					switch {
+					case len(fields) == 0:
+					case fields[0] == "OPERATORS:":
+						operators = append(operators, fields[1:]...)
+					case fields[0] == "QSO:" || fields[0] == "X-QSO:":
+						qsos++
+					}
+				}
+
+				for _, line := range strings.Split(tt.input, "\n") {
+					fields := strings.Fields(line)
+					switch {
+					case len(fields) < 2:
+					case strings.EqualFold(fields[0], "OPERATORS:"):
+						for _, op := range operatorsField(strings.Join(fields[1:], " ")) {
+							require.Contains(t, operators, op)
+						}
+					case strings.EqualFold(fields[0], "CERTIFICATE:") && strings.EqualFold(fields[1], "YES"):
+						// The default isn't written.
+					case strings.EqualFold(fields[0], "QSO:") || strings.EqualFold(fields[0], "X-QSO:"):
+						// The columns are realigned, the round trip test
+						// compares their content.
+						qsos--
+					default:
+						require.True(t, written[formatKey(line)], "line %q is missing from\n%s", line, buf.String())
+					}
+				}
+				require.Zero(t, qsos, "QSO lines are missing from\n%s", buf.String())
+			})
+		}
+	})
+}
+
+// formatKey returns the line with an upper case tag and the fields separated
+// by a single space, except for the SOAPBOX lines which keep their spacing.
+func formatKey(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return ""
+	}
+	tag := strings.ToUpper(fields[0])
+	if tag == "SOAPBOX:" {
+		return tag + " " + tagValue(line)
+	}
+	return strings.Join(append([]string{tag}, fields[1:]...), " ")
+}